@@ -37,3 +37,24 @@ func TestAssignmentAsExpression(t *testing.T) {
 
 	test.RunAndMatch()
 }
+
+func TestBuiltRules(t *testing.T) {
+	ruleSet, err := buildRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test := linttest.NewSuite(t)
+	test.AddFile(`<?php
+	function old_send_mail($to) {}
+
+	function test() {
+		old_send_mail("admin@example.com");
+	}`)
+
+	test.Expect = []string{
+		"old_send_mail is deprecated, use send_mail instead",
+	}
+
+	test.RunRuleSetTest(ruleSet)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/VKCOM/noverify/src/cmd"
 	"github.com/VKCOM/noverify/src/ir"
 	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/rules"
 	"github.com/VKCOM/noverify/src/solver"
 )
 
@@ -25,16 +26,47 @@ func addCheckers(config *linter.Config) {
 	})
 }
 
+// buildRules creates dynamic rules without a rules file.
+// It can be used to generate the rules from some data at startup.
+func buildRules() (*rules.Set, error) {
+	deprecated := map[string]string{
+		"old_send_mail": "send_mail",
+	}
+
+	b := rules.NewBuilder()
+	b.SetDoc("exampleDeprecatedFunc", rules.RuleDoc{
+		Comment: "Report calls of the deprecated project functions.",
+	})
+	for name, replacement := range deprecated {
+		err := b.Add(rules.RuleSpec{
+			Name:    "exampleDeprecatedFunc",
+			Pattern: name + "(${\"*\"})",
+			Level:   linter.LevelWarning,
+			Message: name + " is deprecated, use " + replacement + " instead",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b.Build(), nil
+}
+
 func main() {
 	log.SetFlags(log.Flags() | log.Ltime)
 
 	config := linter.NewConfig("8.1")
 	addCheckers(config)
 
+	ruleSet, err := buildRules()
+	if err != nil {
+		log.Fatalf("build rules: %v", err)
+	}
+
 	// Config argument can be nil to use "all default" behavior.
 	cmd.Main(&cmd.MainConfig{
 		AfterFlagParse: useCustomFlags,
 		LinterConfig:   config,
+		RuleSets:       []*rules.Set{ruleSet},
 	})
 }
 
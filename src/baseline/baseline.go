@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"hash/fnv"
 	"io"
+	"path"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,7 +22,12 @@ import (
 // 1 - initial version.
 // 2 - added Profile.LinterVersion field.
 // 3 - added Profile.CreatedAt field.
-const profileVersion = 3
+// 4 - files are keyed by the project-relative path instead of the base name.
+const profileVersion = 4
+
+// legacyProfileVersion is the latest version that keys files by their base names.
+// Such profiles are still accepted and migrated to the current version on write.
+const legacyProfileVersion = 3
 
 // Profile is a project-wide suppression profile (baseline file).
 type Profile struct {
@@ -30,9 +37,38 @@ type Profile struct {
 	// this profile was generated.
 	CreatedAt int64
 
+	// BaseNameKeys is set for the profiles migrated from the
+	// legacy version where Files are keyed by the file base name.
+	BaseNameKeys bool
+
 	Files map[string]FileProfile
 }
 
+// Lookup returns the suppressed reports for the file with the given project-relative path.
+func (p *Profile) Lookup(filename string) FileProfile {
+	if p.BaseNameKeys {
+		return p.Files[path.Base(filename)]
+	}
+	return p.Files[filename]
+}
+
+// RelativePath returns the slash-separated path of the filename relative
+// to the root directory, the way it's stored in the profile.
+//
+// Files outside of the root are kept as absolute paths.
+func RelativePath(root, filename string) string {
+	if root != "" {
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(root, filename)
+		}
+		rel, err := filepath.Rel(root, filename)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			filename = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(filename))
+}
+
 // FileProfile contains all reports suppressed for the associated file.
 type FileProfile struct {
 	Filename string
@@ -78,8 +114,8 @@ func ReadProfile(r io.Reader) (*Profile, *Stats, error) {
 		return nil, nil, fmt.Errorf("can't decode baseline file: %v (version mismatch?)", err)
 	}
 
-	if p.Version != profileVersion {
-		return nil, nil, fmt.Errorf("version mismatch: want %d, have %d", p.Version, profileVersion)
+	if p.Version != profileVersion && p.Version != legacyProfileVersion {
+		return nil, nil, fmt.Errorf("version mismatch: want %d, have %d", profileVersion, p.Version)
 	}
 
 	files := make(map[string]FileProfile, len(p.Files))
@@ -118,6 +154,7 @@ func ReadProfile(r io.Reader) (*Profile, *Stats, error) {
 	result := &Profile{
 		LinterVersion: p.LinterVersion,
 		CreatedAt:     p.CreatedAt,
+		BaseNameKeys:  p.Version == legacyProfileVersion,
 		Files:         files,
 	}
 	return result, p.Stats, nil
@@ -126,15 +163,23 @@ func ReadProfile(r io.Reader) (*Profile, *Stats, error) {
 // WriteProfile writes a given suppression profile to w.
 //
 // Stats are included into the output as well.
+//
+// Profiles with base name keys are written in the legacy format,
+// use Matcher to migrate them to the project-relative paths.
 func WriteProfile(w io.Writer, p *Profile, stats *Stats) error {
 	const hashesPerLine = 15
 
+	version := profileVersion
+	if p.BaseNameKeys {
+		version = legacyProfileVersion
+	}
+
 	files := make([]jsonFileProfile, 0, len(p.Files))
 	for filename, f := range p.Files {
 		parts := make([]string, 0, len(f.Reports))
 		for hash := range f.Reports {
 			r := f.Reports[hash]
-			part := strconv.FormatUint(hash, 36)
+			part := FormatHash(hash)
 			if r.Count > 1 {
 				part += fmt.Sprintf("*%d", r.Count)
 			}
@@ -176,12 +221,17 @@ func WriteProfile(w io.Writer, p *Profile, stats *Stats) error {
 	return enc.Encode(jsonProfile{
 		LinterVersion: p.LinterVersion,
 		CreatedAt:     p.CreatedAt,
-		Version:       profileVersion,
+		Version:       version,
 		Stats:         stats,
 		Files:         files,
 	})
 }
 
+// FormatHash returns the hash representation that is used in the profile.
+func FormatHash(hash uint64) string {
+	return strconv.FormatUint(hash, 36)
+}
+
 // HashFields is a set of fields that are used during the report hash calculation.
 type HashFields struct {
 	Filename  string
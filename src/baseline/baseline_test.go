@@ -3,6 +3,8 @@ package baseline
 import (
 	"bytes"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -66,7 +68,7 @@ func TestWriteReadBaseline(t *testing.T) {
 	const expectedOutput = `{
 	"LinterVersion": "3cfde307d8fbb5acd13d3c346b442172c4433dcb",
 	"CreatedAt": 1594673910,
-	"Version": 3,
+	"Version": 4,
 	"Stats": {
 		"CountTotal": 0,
 		"CountPerCheck": null
@@ -197,3 +199,194 @@ func TestWriteReadBaseline(t *testing.T) {
 		t.Fatalf("big profile size differs:\nhave: %d\nwant: %d", buf.Len(), expectedSize)
 	}
 }
+
+func TestReadLegacyProfile(t *testing.T) {
+	const input = `{
+	"LinterVersion": "3cfde307d8fbb5acd13d3c346b442172c4433dcb",
+	"CreatedAt": 1594673910,
+	"Version": 3,
+	"Files": [
+		{
+			"File": "index.php",
+			"Hashes": [
+				"zr6nxkhb4gdh*2"
+			]
+		}
+	]
+}
+`
+	p, _, err := ReadProfile(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("read legacy profile: %v", err)
+	}
+	if !p.BaseNameKeys {
+		t.Fatalf("legacy profile is not marked as keyed by base names")
+	}
+
+	hash, _ := strconv.ParseUint("zr6nxkhb4gdh", 36, 64)
+	for _, filename := range []string{"index.php", "a/index.php", "b/c/index.php"} {
+		if have := lookupCount(p, filename, hash); have != 2 {
+			t.Errorf("%s: count mismatch: have %d, want 2", filename, have)
+		}
+	}
+
+	// Used suppressions are migrated to the project-relative paths.
+	m := NewMatcher(p)
+	if !m.Suppress("a/index.php", hash) {
+		t.Fatalf("a/index.php report is not suppressed")
+	}
+	var buf bytes.Buffer
+	if err := WriteProfile(&buf, m.Used(), &Stats{}); err != nil {
+		t.Fatalf("write migrated profile: %v", err)
+	}
+	migrated, _, err := ReadProfile(&buf)
+	if err != nil {
+		t.Fatalf("read migrated profile: %v", err)
+	}
+	if migrated.BaseNameKeys {
+		t.Fatalf("migrated profile is still keyed by base names")
+	}
+	if have := lookupCount(migrated, "a/index.php", hash); have != 1 {
+		t.Errorf("a/index.php: count mismatch: have %d, want 1", have)
+	}
+	if have := lookupCount(migrated, "b/index.php", hash); have != 0 {
+		t.Errorf("b/index.php: count mismatch: have %d, want 0", have)
+	}
+
+	want := []StaleReport{{Filename: "index.php", Hash: hash, Count: 1}}
+	if diff := cmp.Diff(m.Stale(), want); diff != "" {
+		t.Errorf("stale reports differ:\n%s", diff)
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	p := &Profile{
+		Files: map[string]FileProfile{
+			"a/index.php": {
+				Filename: "a/index.php",
+				Reports: map[uint64]Report{
+					10: {Hash: 10, Count: 2},
+					20: {Hash: 20, Count: 1},
+				},
+			},
+			"b/index.php": {
+				Filename: "b/index.php",
+				Reports: map[uint64]Report{
+					10: {Hash: 10, Count: 1},
+				},
+			},
+		},
+	}
+
+	m := NewMatcher(p)
+	suppressed := []bool{
+		m.Suppress("a/index.php", 10),
+		m.Suppress("a/index.php", 10),
+		m.Suppress("a/index.php", 10), // Only 2 reports are suppressed
+		m.Suppress("c/index.php", 10), // Same base name, but another file
+		m.Suppress("a/index.php", 30),
+	}
+	if diff := cmp.Diff(suppressed, []bool{true, true, false, false, false}); diff != "" {
+		t.Errorf("suppress results differ:\n%s", diff)
+	}
+
+	wantStale := []StaleReport{
+		{Filename: "a/index.php", Hash: 20, Count: 1},
+		{Filename: "b/index.php", Hash: 10, Count: 1},
+	}
+	if diff := cmp.Diff(m.Stale(), wantStale); diff != "" {
+		t.Errorf("stale reports differ:\n%s", diff)
+	}
+
+	wantUsed := &Profile{
+		Files: map[string]FileProfile{
+			"a/index.php": {
+				Filename: "a/index.php",
+				Reports: map[uint64]Report{
+					10: {Hash: 10, Count: 2},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(m.Used(), wantUsed); diff != "" {
+		t.Errorf("used profile differs:\n%s", diff)
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		root     string
+		filename string
+		want     string
+	}{
+		{"/project", "/project/a/index.php", "a/index.php"},
+		{"/project", "a/./index.php", "a/index.php"},
+		{"/project", "./index.php", "index.php"},
+		{"/project", "/other/index.php", "/other/index.php"},
+		{"/project", "/project/../project2/index.php", "/project2/index.php"},
+		{"", "a/index.php", "a/index.php"},
+	}
+
+	for _, test := range tests {
+		have := RelativePath(test.root, test.filename)
+		if have != test.want {
+			t.Errorf("RelativePath(%q, %q): have %q, want %q", test.root, test.filename, have, test.want)
+		}
+	}
+}
+
+func lookupCount(p *Profile, filename string, hash uint64) int {
+	f := p.Lookup(filename)
+	return f.Count(hash)
+}
+
+func TestDiff(t *testing.T) {
+	makeProfile := func(baseNameKeys bool, files map[string][]Report) *Profile {
+		p := &Profile{BaseNameKeys: baseNameKeys, Files: make(map[string]FileProfile)}
+		for filename, reports := range files {
+			f := FileProfile{Filename: filename, Reports: make(map[uint64]Report)}
+			for _, r := range reports {
+				f.Reports[r.Hash] = r
+			}
+			p.Files[filename] = f
+		}
+		return p
+	}
+
+	oldProfile := makeProfile(false, map[string][]Report{
+		"a/index.php": {{Hash: 1, Count: 1}, {Hash: 2, Count: 3}},
+		"b/index.php": {{Hash: 3, Count: 1}},
+		"c/Foo.php":   {{Hash: 4, Count: 1}},
+	})
+	newProfile := makeProfile(false, map[string][]Report{
+		"a/index.php": {{Hash: 1, Count: 1}, {Hash: 2, Count: 1}, {Hash: 5, Count: 1}},
+		"c/Foo.php":   {{Hash: 4, Count: 1}},
+		"d/Bar.php":   {{Hash: 6, Count: 2}},
+	})
+
+	want := []FileDiff{
+		{Filename: "a/index.php", Added: 1, Removed: 2},
+		{Filename: "b/index.php", Removed: 1},
+		{Filename: "d/Bar.php", Added: 2},
+	}
+	if diff := cmp.Diff(Diff(oldProfile, newProfile), want); diff != "" {
+		t.Errorf("diff results differ:\n%s", diff)
+	}
+
+	// Migration from the legacy profile is not a change.
+	legacyProfile := makeProfile(true, map[string][]Report{
+		"index.php": {{Hash: 1, Count: 1}, {Hash: 2, Count: 3}, {Hash: 3, Count: 1}},
+		"Foo.php":   {{Hash: 4, Count: 1}},
+	})
+	if diff := Diff(legacyProfile, oldProfile); len(diff) != 0 {
+		t.Errorf("unexpected migration diff: %v", diff)
+	}
+
+	wantPerDir := map[string]int{"a": 4, "b": 1, "c": 1}
+	if diff := cmp.Diff(oldProfile.CountPerDir(), wantPerDir); diff != "" {
+		t.Errorf("per-dir counts differ:\n%s", diff)
+	}
+	if total := oldProfile.CountTotal(); total != 6 {
+		t.Errorf("total count mismatch: have %d, want 6", total)
+	}
+}
@@ -0,0 +1,102 @@
+package baseline
+
+import (
+	"path"
+	"sort"
+)
+
+// FileDiff describes how the suppressions of a single file were changed.
+type FileDiff struct {
+	Filename string
+	Added    int
+	Removed  int
+}
+
+// Diff returns the per-file suppression changes between two profiles,
+// sorted by filename. Unchanged files are not included.
+//
+// If only one of the profiles is keyed by base names, both of them
+// are compared by base names, so the migration itself is not a change.
+func Diff(oldProfile, newProfile *Profile) []FileDiff {
+	byBaseName := oldProfile.BaseNameKeys != newProfile.BaseNameKeys
+	oldCounts := hashCounts(oldProfile, byBaseName)
+	newCounts := hashCounts(newProfile, byBaseName)
+
+	diffs := make(map[string]*FileDiff)
+	getDiff := func(filename string) *FileDiff {
+		d, ok := diffs[filename]
+		if !ok {
+			d = &FileDiff{Filename: filename}
+			diffs[filename] = d
+		}
+		return d
+	}
+
+	for filename, counts := range newCounts {
+		for hash, count := range counts {
+			if delta := count - oldCounts[filename][hash]; delta > 0 {
+				getDiff(filename).Added += delta
+			}
+		}
+	}
+	for filename, counts := range oldCounts {
+		for hash, count := range counts {
+			if delta := count - newCounts[filename][hash]; delta > 0 {
+				getDiff(filename).Removed += delta
+			}
+		}
+	}
+
+	list := make([]FileDiff, 0, len(diffs))
+	for _, d := range diffs {
+		list = append(list, *d)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Filename < list[j].Filename
+	})
+	return list
+}
+
+// CountPerDir returns the number of suppressed reports per directory.
+//
+// Profiles keyed by base names have all files inside the "." directory.
+func (p *Profile) CountPerDir() map[string]int {
+	counts := make(map[string]int)
+	for filename, f := range p.Files {
+		dir := path.Dir(filename)
+		for _, r := range f.Reports {
+			counts[dir] += r.Count
+		}
+	}
+	return counts
+}
+
+// CountTotal returns the number of suppressed reports.
+func (p *Profile) CountTotal() int {
+	total := 0
+	for _, f := range p.Files {
+		for _, r := range f.Reports {
+			total += r.Count
+		}
+	}
+	return total
+}
+
+// hashCounts returns the number of suppressions per file and hash.
+func hashCounts(p *Profile, byBaseName bool) map[string]map[uint64]int {
+	result := make(map[string]map[uint64]int, len(p.Files))
+	for filename, f := range p.Files {
+		if byBaseName {
+			filename = path.Base(filename)
+		}
+		counts := result[filename]
+		if counts == nil {
+			counts = make(map[uint64]int, len(f.Reports))
+			result[filename] = counts
+		}
+		for hash, r := range f.Reports {
+			counts[hash] += r.Count
+		}
+	}
+	return result
+}
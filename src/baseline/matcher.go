@@ -0,0 +1,129 @@
+package baseline
+
+import (
+	"sort"
+)
+
+// Matcher matches reports against the suppression profile
+// and keeps track of the suppressions that were used.
+//
+// It's used to find the stale suppressions as well as
+// to build a new profile that contains only the used ones.
+type Matcher struct {
+	profile *Profile
+
+	// used maps a project-relative file path to the number
+	// of the suppressed reports per hash.
+	used map[string]map[uint64]int
+}
+
+// StaleReport is a suppression that doesn't match any report.
+type StaleReport struct {
+	// Filename is a profile key, it's a file base name for the legacy profiles.
+	Filename string
+
+	Hash uint64
+
+	// Count is a number of unmatched reports with this hash.
+	Count int
+}
+
+// NewMatcher returns a matcher for the given profile.
+// Nil profile is an empty suppression profile.
+func NewMatcher(p *Profile) *Matcher {
+	if p == nil {
+		p = &Profile{}
+	}
+	return &Matcher{
+		profile: p,
+		used:    make(map[string]map[uint64]int),
+	}
+}
+
+// Suppress reports whether the report with a given hash is suppressed.
+// filename is a project-relative path of the reported file.
+func (m *Matcher) Suppress(filename string, hash uint64) bool {
+	f := m.profile.Lookup(filename)
+	count := f.Count(hash)
+	if count == 0 {
+		return false
+	}
+
+	used := m.used[filename]
+	if used == nil {
+		used = make(map[uint64]int)
+		m.used[filename] = used
+	}
+	if used[hash] >= count {
+		return false
+	}
+	used[hash]++
+	return true
+}
+
+// Used returns a profile that contains only the suppressions that were used.
+//
+// The result is keyed by project-relative paths even if
+// the original profile is keyed by base names.
+func (m *Matcher) Used() *Profile {
+	files := make(map[string]FileProfile, len(m.used))
+	for filename, used := range m.used {
+		reports := make(map[uint64]Report, len(used))
+		for hash, count := range used {
+			reports[hash] = Report{Count: count, Hash: hash}
+		}
+		files[filename] = FileProfile{
+			Filename: filename,
+			Reports:  reports,
+		}
+	}
+
+	return &Profile{
+		LinterVersion: m.profile.LinterVersion,
+		CreatedAt:     m.profile.CreatedAt,
+		Files:         files,
+	}
+}
+
+// Stale returns the suppressions that didn't match any report,
+// sorted by the filename and hash.
+func (m *Matcher) Stale() []StaleReport {
+	// For legacy profiles several files share the same key,
+	// so we sum up the usages of all of them.
+	usedByKey := make(map[string]map[uint64]int, len(m.used))
+	for filename, used := range m.used {
+		key := filename
+		if m.profile.BaseNameKeys {
+			key = m.profile.Lookup(filename).Filename
+		}
+		counts := usedByKey[key]
+		if counts == nil {
+			counts = make(map[uint64]int, len(used))
+			usedByKey[key] = counts
+		}
+		for hash, count := range used {
+			counts[hash] += count
+		}
+	}
+
+	var stale []StaleReport
+	for key, f := range m.profile.Files {
+		for hash, r := range f.Reports {
+			if unused := r.Count - usedByKey[key][hash]; unused > 0 {
+				stale = append(stale, StaleReport{
+					Filename: key,
+					Hash:     hash,
+					Count:    unused,
+				})
+			}
+		}
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		if stale[i].Filename != stale[j].Filename {
+			return stale[i].Filename < stale[j].Filename
+		}
+		return stale[i].Hash < stale[j].Hash
+	})
+	return stale
+}
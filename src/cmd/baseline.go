@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/linter"
+)
+
+// BaselineUpdate runs the analysis and rewrites the --baseline profile
+// so it only contains the suppressions that still match some report.
+//
+// New reports are never added to the profile, so it can only shrink.
+func BaselineUpdate(ctx *AppContext) (int, error) {
+	ctx.ParsedFlags.baselineUpdate = true
+	return Check(ctx)
+}
+
+func updateBaseline(l *LinterRunner, cfg *MainConfig, reports []*linter.Report) error {
+	var stats baseline.Stats
+	stats.CountPerCheck = make(map[string]int)
+
+	m := baseline.NewMatcher(l.baselineProfile)
+	for _, r := range reports {
+		if cfg.BeforeReport != nil && !cfg.BeforeReport(r) {
+			continue
+		}
+		if !l.checkersFilter.IsEnabledReport(r.CheckName, r.Filename) {
+			continue
+		}
+		if m.Suppress(baseline.RelativePath(l.projectRoot, r.Filename), r.Hash) {
+			stats.CountTotal++
+			stats.CountPerCheck[r.CheckName]++
+		}
+	}
+
+	profile := m.Used()
+	profile.LinterVersion = cfg.LinterVersion
+	profile.CreatedAt = time.Now().Unix()
+
+	stale := m.Stale()
+	removed := 0
+	for _, s := range stale {
+		removed += s.Count
+	}
+
+	// We write to the buffer first, so the old profile
+	// is kept intact if something goes wrong.
+	var buf bytes.Buffer
+	if err := baseline.WriteProfile(&buf, profile, &stats); err != nil {
+		return err
+	}
+	if err := os.WriteFile(l.flags.Baseline, buf.Bytes(), 0666); err != nil {
+		return err
+	}
+
+	log.Printf("Removed %d stale suppressions, %d suppressions left", removed, stats.CountTotal)
+	return nil
+}
+
+// reportStaleBaseline returns the reports that are not suppressed
+// by the --baseline profile and logs the suppressions that no longer match any report.
+func reportStaleBaseline(l *LinterRunner, reports []*linter.Report) []*linter.Report {
+	m := baseline.NewMatcher(l.baselineProfile)
+	filtered := reports[:0]
+	for _, r := range reports {
+		filename := baseline.RelativePath(l.projectRoot, r.Filename)
+		if !m.Suppress(filename, r.Hash) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	stale := m.Stale()
+	for _, s := range stale {
+		log.Printf("stale baseline suppression: %s: hash %s (x%d)",
+			s.Filename, baseline.FormatHash(s.Hash), s.Count)
+	}
+	if len(stale) != 0 {
+		log.Printf("Found %d stale suppressions, run 'noverify baseline update' to remove them", len(stale))
+	}
+
+	return filtered
+}
+
+// BaselineStats prints the number of suppressed reports per check and per directory.
+func BaselineStats(ctx *AppContext) (int, error) {
+	if len(ctx.ParsedArgs) != 1 {
+		return 1, fmt.Errorf("expected exactly 1 argument, a baseline file")
+	}
+
+	profile, stats, err := readProfileFile(ctx.ParsedArgs[0])
+	if err != nil {
+		return 1, err
+	}
+
+	fmt.Printf("Total: %d suppressed reports in %d files\n", profile.CountTotal(), len(profile.Files))
+	if profile.LinterVersion != "" {
+		fmt.Printf("Created by %s at %s\n", profile.LinterVersion, time.Unix(profile.CreatedAt, 0).Format(time.RFC3339))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Println()
+	fmt.Println("Per check:")
+	if stats == nil || stats.CountPerCheck == nil {
+		fmt.Println("  not available, the baseline has no stats")
+	} else {
+		for _, kv := range sortedCounts(stats.CountPerCheck) {
+			fmt.Fprintf(w, "  %s\t%d\n", kv.key, kv.count)
+		}
+		w.Flush()
+	}
+
+	fmt.Println()
+	fmt.Println("Per directory:")
+	if profile.BaseNameKeys {
+		fmt.Println("  not available, the baseline uses the legacy format keyed by file base names")
+	} else {
+		for _, kv := range sortedCounts(profile.CountPerDir()) {
+			fmt.Fprintf(w, "  %s\t%d\n", kv.key, kv.count)
+		}
+		w.Flush()
+	}
+
+	return 0, nil
+}
+
+// BaselineDiff prints the suppressions that were added or removed between two profiles.
+func BaselineDiff(ctx *AppContext) (int, error) {
+	if len(ctx.ParsedArgs) != 2 {
+		return 1, fmt.Errorf("expected exactly 2 arguments, old and new baseline files")
+	}
+
+	oldProfile, oldStats, err := readProfileFile(ctx.ParsedArgs[0])
+	if err != nil {
+		return 1, err
+	}
+	newProfile, newStats, err := readProfileFile(ctx.ParsedArgs[1])
+	if err != nil {
+		return 1, err
+	}
+
+	diffs := baseline.Diff(oldProfile, newProfile)
+	if len(diffs) == 0 {
+		fmt.Println("No changes")
+		return 0, nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	added, removed := 0, 0
+	fmt.Println("Per file:")
+	for _, d := range diffs {
+		fmt.Fprintf(w, "  %s\t+%d\t-%d\n", d.Filename, d.Added, d.Removed)
+		added += d.Added
+		removed += d.Removed
+	}
+	w.Flush()
+
+	if oldStats != nil && newStats != nil {
+		delta := make(map[string]int)
+		for checkName, count := range newStats.CountPerCheck {
+			delta[checkName] += count
+		}
+		for checkName, count := range oldStats.CountPerCheck {
+			delta[checkName] -= count
+		}
+
+		fmt.Println()
+		fmt.Println("Per check:")
+		for _, kv := range sortedCounts(delta) {
+			if kv.count != 0 {
+				fmt.Fprintf(w, "  %s\t%+d\n", kv.key, kv.count)
+			}
+		}
+		w.Flush()
+	}
+
+	fmt.Println()
+	fmt.Printf("Total: +%d -%d suppressed reports\n", added, removed)
+
+	return 0, nil
+}
+
+// BaselineExplain prints the reports from the JSON report file
+// that are suppressed by the profile.
+func BaselineExplain(ctx *AppContext) (int, error) {
+	if len(ctx.ParsedArgs) != 2 {
+		return 1, fmt.Errorf("expected exactly 2 arguments, a baseline file and a JSON report file")
+	}
+
+	profile, _, err := readProfileFile(ctx.ParsedArgs[0])
+	if err != nil {
+		return 1, err
+	}
+
+	data, err := os.ReadFile(ctx.ParsedArgs[1])
+	if err != nil {
+		return 1, err
+	}
+	var list struct {
+		Reports []*linter.Report
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return 1, fmt.Errorf("decode %s: %v", ctx.ParsedArgs[1], err)
+	}
+
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return 1, err
+	}
+
+	m := baseline.NewMatcher(profile)
+	suppressed := 0
+	for _, r := range list.Reports {
+		if r.Hash == 0 {
+			return 1, fmt.Errorf("%s:%d: report has no hash, use the report file created by --output-json", r.Filename, r.Line)
+		}
+		filename := baseline.RelativePath(projectRoot, r.Filename)
+		if !m.Suppress(filename, r.Hash) {
+			continue
+		}
+		suppressed++
+		fmt.Printf("%s:%d: %s: %s (hash %s)\n", r.Filename, r.Line, r.CheckName, r.Message, baseline.FormatHash(r.Hash))
+	}
+
+	fmt.Printf("%d of %d reports are suppressed by %s\n", suppressed, len(list.Reports), ctx.ParsedArgs[0])
+
+	return 0, nil
+}
+
+func readProfileFile(filename string) (*baseline.Profile, *baseline.Stats, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	profile, stats, err := baseline.ReadProfile(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return profile, stats, nil
+}
+
+type keyCount struct {
+	key   string
+	count int
+}
+
+// sortedCounts returns the map elements sorted by the count
+// in descending order, elements with equal counts are sorted by the key.
+func sortedCounts(m map[string]int) []keyCount {
+	list := make([]keyCount, 0, len(m))
+	for key, count := range m {
+		list = append(list, keyCount{key: key, count: count})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].count != list[j].count {
+			return list[i].count > list[j].count
+		}
+		return list[i].key < list[j].key
+	})
+	return list
+}
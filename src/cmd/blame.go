@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/VKCOM/noverify/src/git"
+	"github.com/VKCOM/noverify/src/linter"
+)
+
+// notCommittedAuthor is used for the lines that are not committed yet.
+const notCommittedAuthor = "Not Committed Yet"
+
+// annotateBlame fills the author, commit and date of the report lines.
+//
+// Files that can't be blamed (e.g. they're outside of a git repository)
+// are logged and their reports are left as is.
+func annotateBlame(reports []*linter.Report, maxConcurrency int) {
+	byFile := make(map[string][]*linter.Report)
+	for _, r := range reports {
+		byFile[r.Filename] = append(byFile[r.Filename], r)
+	}
+
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	limitCh := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for filename, list := range byFile {
+		wg.Add(1)
+		go func(filename string, list []*linter.Report) {
+			limitCh <- struct{}{}
+			defer func() { <-limitCh }()
+			defer wg.Done()
+
+			lines, err := git.BlameAuthors(filename)
+			if err != nil {
+				log.Printf("blame %s: %v", filename, err)
+				return
+			}
+			for _, r := range list {
+				info, ok := lines[r.Line]
+				if !ok {
+					continue
+				}
+				r.Commit = info.Commit
+				r.Author = info.Author
+				if info.Commit == git.Zero {
+					r.Author = notCommittedAuthor
+				} else {
+					r.Date = info.Time.Format(time.RFC3339)
+				}
+			}
+		}(filename, list)
+	}
+	wg.Wait()
+
+	log.Printf("Blamed %d files in %s", len(byFile), time.Since(start))
+}
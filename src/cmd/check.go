@@ -25,11 +25,26 @@ func Check(ctx *AppContext) (int, error) {
 	}
 
 	for _, rset := range ruleSets {
-		config.Checkers.DeclareRules(rset)
+		if err := declareRules(config.Checkers, rset); err != nil {
+			return 1, fmt.Errorf("preload external rules: %v", err)
+		}
 	}
 
 	ctx.MainConfig.rulesSets = append(ctx.MainConfig.rulesSets, ruleSets...)
 
+	packRuleSets, err := selectRulePacks(ctx.MainConfig.rulePacks, ctx.ParsedFlags.RulePacks)
+	if err != nil {
+		return 1, err
+	}
+
+	for _, rset := range packRuleSets {
+		if err := declareRules(config.Checkers, rset); err != nil {
+			return 1, err
+		}
+	}
+
+	ctx.MainConfig.rulesSets = append(ctx.MainConfig.rulesSets, packRuleSets...)
+
 	if ctx.ParsedFlags.DisableCache {
 		config.CacheDir = ""
 	}
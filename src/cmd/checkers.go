@@ -11,6 +11,11 @@ import (
 )
 
 func Checkers(ctx *AppContext) (int, error) {
+	config := ctx.MainConfig.linter.Config()
+	if err := declareRulePacks(config.Checkers, ctx.MainConfig.rulePacks); err != nil {
+		return 1, err
+	}
+
 	// `checkers`
 	if len(ctx.ParsedArgs) == 0 {
 		showCheckersList(ctx)
@@ -20,7 +25,7 @@ func Checkers(ctx *AppContext) (int, error) {
 	checkerName := ctx.ParsedArgs[0]
 
 	// `checkers <name>`
-	err := showCheckerInfo(ctx.MainConfig.linter.Config(), checkerName)
+	err := showCheckerInfo(config, checkerName)
 	if err != nil {
 		return 1, err
 	}
@@ -39,7 +44,7 @@ func showCheckersList(ctx *AppContext) {
 		if !info.Default {
 			continue
 		}
-		fmt.Fprintf(w, "   %s\t%s\n", info.Name, strings.ReplaceAll(info.Comment, "\n", " "))
+		fmt.Fprintf(w, "   %s\t%s\n", info.Name, checkerListComment(info))
 	}
 	w.Flush()
 
@@ -50,7 +55,15 @@ func showCheckersList(ctx *AppContext) {
 		if info.Default {
 			continue
 		}
-		fmt.Fprintf(w, "   %s\t%s\n", info.Name, strings.ReplaceAll(info.Comment, "\n", " "))
+		fmt.Fprintf(w, "   %s\t%s\n", info.Name, checkerListComment(info))
+	}
+	w.Flush()
+
+	fmt.Println()
+	fmt.Println("Rule packs (enabled by --rule-packs):")
+
+	for _, pack := range ctx.MainConfig.rulePacks {
+		fmt.Fprintf(w, "   %s\t%s\t%s\n", pack.Name, pack.Version, pack.Comment)
 	}
 	w.Flush()
 
@@ -59,6 +72,16 @@ func showCheckersList(ctx *AppContext) {
 	fmt.Println("   $ noverify checkers <checker-name>")
 }
 
+// checkerListComment returns a one-line checker description
+// that is prefixed with the rule pack name, if any.
+func checkerListComment(info linter.CheckerInfo) string {
+	comment := strings.ReplaceAll(info.Comment, "\n", " ")
+	if info.Pack != "" {
+		return "[" + info.Pack + "] " + comment
+	}
+	return comment
+}
+
 func showCheckerInfo(config *linter.Config, checkerName string) error {
 	var info linter.CheckerInfo
 	checks := config.Checkers.ListDeclared()
@@ -10,6 +10,9 @@ import (
 
 func CheckersDocumentation(ctx *AppContext) (int, error) {
 	config := ctx.MainConfig.linter.Config()
+	if err := declareRulePacks(config.Checkers, ctx.MainConfig.rulePacks); err != nil {
+		return 1, err
+	}
 
 	fmt.Println("# Checkers")
 
@@ -30,12 +33,7 @@ func CheckersDocumentation(ctx *AppContext) (int, error) {
 		if !info.Default {
 			continue
 		}
-		autofixable := ""
-		if info.Quickfix {
-			autofixable = " (autofixable)"
-		}
-
-		fmt.Printf("   - [`%s` checker%s](#%s-checker)\n", info.Name, autofixable, strings.ToLower(info.Name))
+		fmt.Printf("   - [`%s` checker%s](#%s-checker)\n", info.Name, checkerTOCAttrs(info), strings.ToLower(info.Name))
 	}
 
 	fmt.Println(" - Disabled by default")
@@ -43,12 +41,21 @@ func CheckersDocumentation(ctx *AppContext) (int, error) {
 		if info.Default {
 			continue
 		}
-		autofixable := ""
-		if info.Quickfix {
-			autofixable = " (autofixable)"
-		}
+		fmt.Printf("   - [`%s` checker%s](#%s-checker)\n", info.Name, checkerTOCAttrs(info), strings.ToLower(info.Name))
+	}
 
-		fmt.Printf("   - [`%s` checker%s](#%s-checker)\n", info.Name, autofixable, strings.ToLower(info.Name))
+	if len(ctx.MainConfig.rulePacks) != 0 {
+		fmt.Println()
+		fmt.Println("## Rule packs")
+		fmt.Println()
+		fmt.Println("The checkers from the rule packs are only available when the pack is enabled with `--rule-packs`.")
+		fmt.Println()
+		fmt.Println("| Pack | Version | Description |")
+		fmt.Println("| ---- | ------- | ----------- |")
+		for _, pack := range ctx.MainConfig.rulePacks {
+			fmt.Printf("| `%s` | %s | %s |\n", pack.Name, pack.Version, pack.Comment)
+		}
+		fmt.Println()
 	}
 
 	fmt.Println("## Enabled")
@@ -80,6 +87,19 @@ func CheckersDocumentation(ctx *AppContext) (int, error) {
 	return 0, nil
 }
 
+// checkerTOCAttrs returns the checker attributes
+// that are shown in the table of contents.
+func checkerTOCAttrs(info linter.CheckerInfo) string {
+	var attrs string
+	if info.Quickfix {
+		attrs += " (autofixable)"
+	}
+	if info.Pack != "" {
+		attrs += fmt.Sprintf(" (`%s` pack)", info.Pack)
+	}
+	return attrs
+}
+
 func checkersStat(checkers []linter.CheckerInfo) (countEnabledDefault int, countDisabledDefault int, countAutofixable int) {
 	for _, info := range checkers {
 		if info.Default {
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+)
+
+// checkstyleVersion is the Checkstyle report format version
+// that is understood by most of the CI plugins.
+const checkstyleVersion = "4.3"
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// writeCheckstyleReports writes the reports in the Checkstyle XML format.
+//
+// File paths are relative to the project root.
+// Critical reports have the error severity, other reports
+// are mapped from their level.
+func writeCheckstyleReports(w io.Writer, runner *LinterRunner, reports []*linter.Report) error {
+	result := checkstyleReport{Version: checkstyleVersion}
+
+	filenames, byFile := groupReportsByFile(reports)
+	for _, filename := range filenames {
+		file := checkstyleFile{Name: baseline.RelativePath(runner.projectRoot, filename)}
+		for _, r := range byFile[filename] {
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     r.Line,
+				Column:   r.StartChar + 1,
+				Severity: checkstyleSeverity(runner, r),
+				Message:  r.Message,
+				Source:   "noverify." + r.CheckName,
+			})
+		}
+		result.Files = append(result.Files, file)
+	}
+
+	return writeXML(w, result)
+}
+
+func checkstyleSeverity(runner *LinterRunner, r *linter.Report) string {
+	if runner.checkersFilter.IsCriticalReport(r) {
+		return "error"
+	}
+	if r.Level == lintapi.LevelNotice {
+		return "info"
+	}
+	return "warning"
+}
+
+// writeXML writes the indented XML document with the header.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -90,14 +90,14 @@ func (a *App) GetRawCommandByName(name string) *Command {
 	return nil
 }
 
-func (a *App) addHelpCommands(commands []*Command) {
+func (a *App) addHelpCommands(commands []*Command, prefix string) {
 	for _, command := range commands {
 		if command.Name != "help" {
-			a.addDefaultHelpCommand(command)
+			a.addDefaultHelpCommand(command, prefix+command.Name)
 		}
 
 		if len(command.Commands) > 0 {
-			a.addHelpCommands(command.Commands)
+			a.addHelpCommands(command.Commands, prefix+command.Name+" ")
 		}
 	}
 }
@@ -107,7 +107,7 @@ func (a *App) prepareCommands() {
 		a.commands = map[string]*Command{}
 	}
 
-	a.addHelpCommands(a.Commands)
+	a.addHelpCommands(a.Commands, "")
 
 	for _, command := range a.Commands {
 		a.commands[command.Name] = command
@@ -125,7 +125,9 @@ func (a *App) prepareCommands() {
 	}
 }
 
-func (a *App) addDefaultHelpCommand(command *Command) {
+// addDefaultHelpCommand adds the help sub-command for the command,
+// fullName is a command name with all its parent command names.
+func (a *App) addDefaultHelpCommand(command *Command, fullName string) {
 	if command.Pure {
 		return
 	}
@@ -160,14 +162,14 @@ func (a *App) addDefaultHelpCommand(command *Command) {
 			}
 
 			res += fmt.Sprintln("Usage:")
-			res += fmt.Sprintf("  $ %s %s%s%s - %s\n", a.CLIName, command.Name, options, args, command.Description)
+			res += fmt.Sprintf("  $ %s %s%s%s - %s\n", a.CLIName, fullName, options, args, command.Description)
 
 			if len(command.Examples) > 0 {
 				res += fmt.Sprintln()
 				res += fmt.Sprintln("Examples:")
 
 				for _, example := range command.Examples {
-					res += fmt.Sprintf("  $ %s %s %s - %s\n", a.CLIName, command.Name, example.Line, example.Description)
+					res += fmt.Sprintf("  $ %s %s %s - %s\n", a.CLIName, fullName, example.Line, example.Description)
 				}
 			}
 
@@ -270,6 +272,21 @@ func (a *App) getCommandByArgs(args []string, commands map[string]*Command) (*Co
 	return command, true
 }
 
+// commandDepth returns the number of leading args that form
+// the command path, like 2 for the "baseline update" command.
+func commandDepth(args []string, commands map[string]*Command) int {
+	depth := 0
+	for depth < len(args) {
+		command, found := commands[args[depth]]
+		if !found {
+			break
+		}
+		depth++
+		commands = command.commands
+	}
+	return depth
+}
+
 func (a *App) Run(cfg *MainConfig) (int, error) {
 	os.Args = os.Args[1:]
 
@@ -305,6 +322,10 @@ func (a *App) Run(cfg *MainConfig) (int, error) {
 		return 0, nil
 	}
 
+	// Sub-command names are not the command arguments,
+	// so we leave only the last command name in os.Args.
+	os.Args = os.Args[commandDepth(os.Args, a.commands)-1:]
+
 	ctx := &AppContext{
 		App:         a,
 		MainConfig:  cfg,
@@ -319,32 +340,21 @@ func (a *App) Run(cfg *MainConfig) (int, error) {
 	}
 
 	var fs *flag.FlagSet
-	var groups *FlagsGroups
 
 	if command.RegisterFlags != nil {
-		fs, groups = command.RegisterFlags(ctx)
+		fs, ctx.flagGroups = command.RegisterFlags(ctx)
 		fs.Usage = nil
-
-		// We don't need any standard output, so we disable it.
-		a.disableDefaultFlagsOutput(fs)
-
-		err := fs.Parse(os.Args[1:])
-		if err != nil {
-			return a.flagNotFound(command.Name, err, ctx)
-		}
-		command.flagSet = fs
-		ctx.ParsedArgs = fs.Args()
-		ctx.FlagSet = fs
-		ctx.flagGroups = groups
 	} else {
 		fs = flag.NewFlagSet("empty", flag.ContinueOnError)
-		// We don't need any standard output, so we disable it.
-		a.disableDefaultFlagsOutput(fs)
 	}
 
+	// We don't need any standard output, so we disable it.
+	a.disableDefaultFlagsOutput(fs)
+
+	// Flags are parsed only once, since the flags that
+	// can be passed several times accumulate the values.
 	err := fs.Parse(os.Args[1:])
 	if err != nil {
-
 		return a.flagNotFound(command.Name, err, ctx)
 	}
 
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitCodeOwnersOnDemand(t *testing.T) {
+	dir := t.TempDir()
+	malformed := "[Backend\n/src/ @backend\n"
+	if err := os.WriteFile(filepath.Join(dir, "CODEOWNERS"), []byte(malformed), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		flags ParsedFlags
+		err   bool
+	}{
+		{flags: ParsedFlags{}},
+		{flags: ParsedFlags{OutputFormat: "json"}},
+		{flags: ParsedFlags{Owner: "@backend"}, err: true},
+		{flags: ParsedFlags{GroupBy: "owner"}, err: true},
+		{flags: ParsedFlags{CodeOwners: filepath.Join(dir, "CODEOWNERS")}, err: true},
+	}
+
+	for _, test := range tests {
+		flags := test.flags
+		l := &LinterRunner{flags: &flags, projectRoot: dir}
+		err := l.initCodeOwners()
+		if test.err != (err != nil) {
+			t.Errorf("%+v: have error %v, want error %v", test.flags, err, test.err)
+		}
+		if err == nil && l.codeOwners != nil {
+			t.Errorf("%+v: CODEOWNERS is loaded", test.flags)
+		}
+	}
+}
+
+func TestInitCodeOwnersNotFound(t *testing.T) {
+	l := &LinterRunner{flags: &ParsedFlags{Owner: "@backend"}, projectRoot: t.TempDir()}
+	err := l.initCodeOwners()
+	if err == nil || !strings.Contains(err.Error(), "CODEOWNERS file is not found") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
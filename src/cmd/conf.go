@@ -33,6 +33,11 @@ type MainConfig struct {
 	LinterConfig *linter.Config
 	linter       *linter.Linter
 	rulesSets    []*rules.Set
+	rulePacks    []*RulePack
+
+	// RuleSets are the additional rule sets, like the ones created by rules.Builder.
+	// Their rules are declared as checkers and used like the embedded rules.
+	RuleSets []*rules.Set
 
 	// RegisterCheckers is used to register additional checkers.
 	RegisterCheckers func() []linter.CheckerInfo
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/VKCOM/noverify/src/git"
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/workspace"
+)
+
+// diffFileMain lints the files changed by the --diff-file patch
+// and reports only the warnings on the lines added by it.
+//
+// Unlike the --git mode, it doesn't need a git repository: the patch
+// is expected to be already applied to the analyzed files.
+func diffFileMain(runner *LinterRunner, ctx *AppContext) (status int, err error) {
+	changes, err := readDiffFile(runner.flags.DiffFile)
+	if err != nil {
+		return 1, fmt.Errorf("read diff file: %v", err)
+	}
+
+	// Patch paths are relative to the directory the patch is applied in.
+	changed := make(map[string]git.Change, len(changes))
+	var changedFiles []string
+	for _, c := range changes {
+		if c.Type == git.Deleted {
+			continue
+		}
+		filename, err := filepath.Abs(filepath.FromSlash(c.NewName))
+		if err != nil {
+			return 1, err
+		}
+		if _, err := os.Stat(filename); err != nil {
+			log.Printf("Skipping %s from the diff: %v", c.NewName, err)
+			continue
+		}
+		changed[filepath.ToSlash(filename)] = c
+		changedFiles = append(changedFiles, filename)
+	}
+
+	filenames := ctx.ParsedArgs
+
+	start := time.Now()
+	log.Printf("Indexing %+v", filenames)
+	runner.linter.AnalyzeFiles(workspace.ReadFilenames(filenames, nil, runner.config.PhpExtensions))
+	parseIndexOnlyFiles(runner)
+	runner.linter.MetaInfo().SetIndexingComplete(true)
+	log.Printf("Indexing complete in %s", time.Since(start))
+
+	start = time.Now()
+	reports := runner.linter.AnalyzeFiles(workspace.ReadFilenames(changedFiles, runner.filenameFilter, runner.config.PhpExtensions))
+	log.Printf("Parsed %d changed files in %s", len(changedFiles), time.Since(start))
+
+	reports = filterAddedLinesReports(reports, changed)
+
+	stat, err := processReports(runner, ctx.MainConfig, reports)
+	if err != nil {
+		return 1, err
+	}
+	status = processReportsStat(ctx, stat)
+
+	return status, nil
+}
+
+func readDiffFile(filename string) ([]git.Change, error) {
+	var r io.Reader = os.Stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return git.ParseDiff(r)
+}
+
+// filterAddedLinesReports returns the reports that point to the lines added by the changes.
+// changes are keyed by the absolute slash-separated file path.
+func filterAddedLinesReports(reports []*linter.Report, changes map[string]git.Change) []*linter.Report {
+	filtered := reports[:0]
+	for _, r := range reports {
+		c, ok := changes[filepath.ToSlash(r.Filename)]
+		if !ok {
+			continue
+		}
+		line := git.LineRange{From: r.Line, To: r.Line}
+		if git.LineRangesIntersect(line, c.AddedLines) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,72 @@
+<?php
+
+/**
+ * @noinspection ALL
+ * @linter       disable
+ */
+
+/**
+ * @comment Report dynamic variable access that is not supported by KPHP.
+ * @before  $$name = 1;
+ * @after   $vars[$name] = 1;
+ */
+function kphpVarVars() {
+  /**
+   * @error Variable variables are not supported by KPHP
+   */
+  any_var_var: {
+    $${"expr"};
+    $${"expr"} = $_;
+  }
+}
+
+/**
+ * @comment Report the functions that work with the local scope by name and are not supported by KPHP.
+ * @before  extract($row);
+ * @after   $id = $row['id'];
+ */
+function kphpScopeFuncs() {
+  /**
+   * @error extract is not supported by KPHP
+   */
+  extract(${"*"});
+
+  /**
+   * @error compact is not supported by KPHP
+   */
+  compact(${"*"});
+
+  /**
+   * @error get_defined_vars is not supported by KPHP
+   */
+  get_defined_vars();
+}
+
+/**
+ * @comment Report dynamic code execution that is not supported by KPHP.
+ * @before  call_user_func_array([$obj, $method], $args);
+ * @after   $obj->method(...$args);
+ */
+function kphpDynamicCall() {
+  /**
+   * @error Calling a method by its dynamic name is not supported by KPHP
+   */
+  $_->${"var"}(${"*"});
+
+  /**
+   * @error Creating an object of a dynamic class is not supported by KPHP
+   * @strict-syntax
+   */
+  new ${"var"}(${"*"});
+
+  /**
+   * @error Creating an object of a dynamic class is not supported by KPHP
+   * @strict-syntax
+   */
+  new ${"var"};
+
+  /**
+   * @error create_function is not supported by KPHP
+   */
+  create_function(${"*"});
+}
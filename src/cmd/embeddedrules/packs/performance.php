@@ -0,0 +1,72 @@
+<?php
+
+/**
+ * @noinspection ALL
+ * @linter       disable
+ */
+
+/**
+ * @comment Report searching among the array keys with a linear scan.
+ * @before  in_array($key, array_keys($arr))
+ * @after   array_key_exists($key, $arr)
+ */
+function keysLinearSearch() {
+  /**
+   * @maybe Could rewrite as `array_key_exists($key, $arr)`
+   * @fix array_key_exists($key, $arr)
+   */
+  in_array($key, array_keys($arr));
+
+  /**
+   * @maybe Could rewrite as `array_key_exists($key, $arr)`
+   * @fix array_key_exists($key, $arr)
+   */
+  in_array($key, array_keys($arr), true);
+}
+
+/**
+ * @comment Report `array_merge` calls that copy the accumulated array on every loop iteration.
+ * @before  foreach ($xs as $x) { $result = array_merge($result, $x); }
+ * @after   $result = array_merge($result, ...$xs);
+ */
+function mergeInLoop() {
+  /**
+   * @maybe array_merge inside a loop copies $dst on every iteration, collect the arrays and merge them once
+   * @inside-loop
+   */
+  $dst = array_merge($dst, ${"*"});
+}
+
+/**
+ * @comment Report loop conditions that call `count` on every iteration.
+ * @before  for ($i = 0; $i < count($xs); $i++) { ... }
+ * @after   for ($i = 0, $n = count($xs); $i < $n; $i++) { ... }
+ */
+function countInLoopCond() {
+  /**
+   * @maybe count($xs) is evaluated on every iteration, save it to a variable before the loop
+   */
+  any_for_count: {
+    for ($_; $_ < count($xs); $_) { ${"*"}; }
+    for ($_; $_ <= count($xs); $_) { ${"*"}; }
+  }
+}
+
+/**
+ * @comment Report `array_search` calls that only check whether the value exists.
+ * @before  array_search($x, $arr) !== false
+ * @after   in_array($x, $arr)
+ */
+function searchForExistence() {
+  /**
+   * @maybe Could rewrite as `in_array($x, $arr)`
+   * @fix in_array($x, $arr)
+   */
+  array_search($x, $arr) !== false;
+
+  /**
+   * @maybe Could rewrite as `in_array($x, $arr, true)`
+   * @fix in_array($x, $arr, true)
+   */
+  array_search($x, $arr, true) !== false;
+}
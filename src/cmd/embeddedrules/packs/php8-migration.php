@@ -0,0 +1,114 @@
+<?php
+
+/**
+ * @noinspection ALL
+ * @linter       disable
+ */
+
+/**
+ * @comment Report substring checks that can use `str_contains`.
+ * @before  strpos($haystack, $needle) !== false
+ * @after   str_contains($haystack, $needle)
+ */
+function strContains() {
+  /**
+   * @maybe Could rewrite as `str_contains($haystack, $needle)`
+   * @fix str_contains($haystack, $needle)
+   * @if-php-version >=8.0
+   */
+  strpos($haystack, $needle) !== false;
+
+  /**
+   * @maybe Could rewrite as `str_contains($haystack, $needle)`
+   * @fix str_contains($haystack, $needle)
+   * @if-php-version >=8.0
+   */
+  false !== strpos($haystack, $needle);
+
+  /**
+   * @maybe Could rewrite as `!str_contains($haystack, $needle)`
+   * @fix !str_contains($haystack, $needle)
+   * @if-php-version >=8.0
+   */
+  strpos($haystack, $needle) === false;
+
+  /**
+   * @maybe Could rewrite as `!str_contains($haystack, $needle)`
+   * @fix !str_contains($haystack, $needle)
+   * @if-php-version >=8.0
+   */
+  false === strpos($haystack, $needle);
+}
+
+/**
+ * @comment Report prefix checks that can use `str_starts_with`.
+ * @before  strpos($haystack, $needle) === 0
+ * @after   str_starts_with($haystack, $needle)
+ */
+function strStartsWith() {
+  /**
+   * @maybe Could rewrite as `str_starts_with($haystack, $needle)`
+   * @fix str_starts_with($haystack, $needle)
+   * @if-php-version >=8.0
+   */
+  strpos($haystack, $needle) === 0;
+
+  /**
+   * @maybe Could rewrite as `str_starts_with($haystack, $needle)`
+   * @fix str_starts_with($haystack, $needle)
+   * @if-php-version >=8.0
+   */
+  0 === strpos($haystack, $needle);
+}
+
+/**
+ * @comment Report suffix checks that can use `str_ends_with`.
+ * @before  substr($haystack, -strlen($needle)) === $needle
+ * @after   str_ends_with($haystack, $needle)
+ */
+function strEndsWith() {
+  /**
+   * @maybe Could rewrite as `str_ends_with($haystack, $needle)`
+   * @fix str_ends_with($haystack, $needle)
+   * @if-php-version >=8.0
+   * @pure $needle
+   */
+  substr($haystack, -strlen($needle)) === $needle;
+
+  /**
+   * @maybe Could rewrite as `str_ends_with($haystack, $needle)`
+   * @fix str_ends_with($haystack, $needle)
+   * @if-php-version >=8.0
+   * @pure $needle
+   */
+  $needle === substr($haystack, -strlen($needle));
+}
+
+/**
+ * @comment Report the functions that were removed in PHP 8.
+ * @before  create_function('$x', 'return $x;')
+ * @after   fn($x) => $x
+ */
+function removedInPhp8() {
+  /**
+   * @error create_function was removed in PHP 8.0, use a closure instead
+   */
+  create_function(${"*"});
+
+  /**
+   * @error each was removed in PHP 8.0, use foreach instead
+   */
+  each($_);
+
+  /**
+   * @error money_format was removed in PHP 8.0, use NumberFormatter instead
+   */
+  money_format(${"*"});
+
+  /**
+   * @error Calling implode with the array as the first argument was removed in PHP 8.0
+   * @type array $arr
+   * @type string $sep
+   */
+  implode($arr, $sep);
+}
@@ -0,0 +1,104 @@
+<?php
+
+/**
+ * @noinspection ALL
+ * @linter       disable
+ */
+
+/**
+ * @comment Report comparisons with literals that have a dedicated PHPUnit assertion.
+ * @before  $this->assertSame(true, $x);
+ * @after   $this->assertTrue($x);
+ */
+function assertLiteral() {
+  /**
+   * @maybe Could rewrite as `$t->assertTrue($x)`
+   * @fix $t->assertTrue($x)
+   */
+  $t->assertSame(true, $x);
+
+  /**
+   * @maybe Could rewrite as `$t->assertTrue($x)`
+   * @fix $t->assertTrue($x)
+   */
+  $t->assertEquals(true, $x);
+
+  /**
+   * @maybe Could rewrite as `$t->assertFalse($x)`
+   * @fix $t->assertFalse($x)
+   */
+  $t->assertSame(false, $x);
+
+  /**
+   * @maybe Could rewrite as `$t->assertFalse($x)`
+   * @fix $t->assertFalse($x)
+   */
+  $t->assertEquals(false, $x);
+
+  /**
+   * @maybe Could rewrite as `$t->assertNull($x)`
+   * @fix $t->assertNull($x)
+   */
+  $t->assertSame(null, $x);
+
+  /**
+   * @maybe Could rewrite as `$t->assertNull($x)`
+   * @fix $t->assertNull($x)
+   */
+  $t->assertEquals(null, $x);
+}
+
+/**
+ * @comment Report comparisons of the array size that can use `assertCount`.
+ * @before  $this->assertSame(3, count($xs));
+ * @after   $this->assertCount(3, $xs);
+ */
+function assertCountCall() {
+  /**
+   * @maybe Could rewrite as `$t->assertCount($n, $xs)`
+   * @fix $t->assertCount($n, $xs)
+   */
+  $t->assertSame($n, count($xs));
+
+  /**
+   * @maybe Could rewrite as `$t->assertCount($n, $xs)`
+   * @fix $t->assertCount($n, $xs)
+   */
+  $t->assertEquals($n, count($xs));
+
+  /**
+   * @maybe Could rewrite as `$t->assertEmpty($xs)`
+   * @fix $t->assertEmpty($xs)
+   */
+  $t->assertTrue(empty($xs));
+}
+
+/**
+ * @comment Report boolean assertions that have a more descriptive alternative.
+ * @before  $this->assertTrue($x instanceof Foo);
+ * @after   $this->assertInstanceOf(Foo::class, $x);
+ */
+function assertSpecific() {
+  /**
+   * @maybe Could rewrite as `$t->assertInstanceOf(...)`, it gives a better failure message
+   */
+  $t->assertTrue($_ instanceof $_);
+
+  /**
+   * @maybe Could rewrite as `$t->assertArrayHasKey($key, $arr)`
+   * @fix $t->assertArrayHasKey($key, $arr)
+   */
+  $t->assertTrue(array_key_exists($key, $arr));
+
+  /**
+   * @maybe Could rewrite as `$t->assertContains($x, $arr)`
+   * @fix $t->assertContains($x, $arr)
+   */
+  $t->assertTrue(in_array($x, $arr, true));
+
+  /**
+   * @maybe Could rewrite as `$t->assertSame($x, $y)`
+   * @fix $t->assertSame($x, $y)
+   */
+  $t->assertTrue($x === $y);
+}
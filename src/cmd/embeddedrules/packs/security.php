@@ -0,0 +1,90 @@
+<?php
+
+/**
+ * @noinspection ALL
+ * @linter       disable
+ */
+
+/**
+ * @comment Report `unserialize` calls that can instantiate arbitrary classes.
+ * @before  unserialize($data);
+ * @after   unserialize($data, ['allowed_classes' => false]);
+ */
+function unsafeUnserialize() {
+  /**
+   * @warning Call unserialize with the 'allowed_classes' option to restrict the objects it creates
+   */
+  unserialize($_);
+}
+
+/**
+ * @comment Report the functions that run shell commands.
+ * @before  exec("ls $dir");
+ * @after   scandir($dir);
+ */
+function shellCommand() {
+  /**
+   * @warning Running shell commands is dangerous, make sure the arguments are escaped
+   */
+  any_shell_call: {
+    exec(${"*"});
+    shell_exec(${"*"});
+    system(${"*"});
+    passthru(${"*"});
+    popen(${"*"});
+    proc_open(${"*"});
+  }
+}
+
+/**
+ * @comment Report importing the user input into the local scope.
+ * @before  extract($_GET);
+ * @after   $id = $_GET['id'] ?? null;
+ */
+function extractUserInput() {
+  /**
+   * @error Don't extract the user input, it can overwrite any local variable
+   * @filter $input ^_(GET|POST|REQUEST|COOKIE|FILES)$
+   */
+  extract($input, ${"*"});
+
+  /**
+   * @error Don't parse the string into variables, it can overwrite any local variable
+   */
+  parse_str($_);
+}
+
+/**
+ * @comment Report hash functions that are not suitable for passwords and signatures.
+ * @before  md5($password);
+ * @after   password_hash($password, PASSWORD_DEFAULT);
+ */
+function weakHash() {
+  /**
+   * @maybe md5 is a weak hash, use password_hash() for passwords and hash_hmac() with sha256 for signatures
+   */
+  md5(${"*"});
+
+  /**
+   * @maybe sha1 is a weak hash, use password_hash() for passwords and hash_hmac() with sha256 for signatures
+   */
+  sha1(${"*"});
+}
+
+/**
+ * @comment Report comparing secrets with the operators that are vulnerable to timing attacks.
+ * @before  $token == $expected
+ * @after   hash_equals($expected, $token)
+ * @disabled
+ */
+function timingUnsafeCompare() {
+  /**
+   * @maybe Use hash_equals() to compare the hashes in constant time
+   */
+  any_hash_compare: {
+    $_ === hash_hmac(${"*"});
+    hash_hmac(${"*"}) === $_;
+    $_ == hash_hmac(${"*"});
+    hash_hmac(${"*"}) == $_;
+  }
+}
@@ -30,10 +30,22 @@ type ParsedFlags struct {
 	CheckAutoGenerated bool
 
 	RulesList string
+	RulePacks string
+
+	Output       string
+	OutputJSON   bool
+	OutputFormat string
+
+	OutputTemplate       string
+	OutputTemplateHeader string
+	OutputTemplateFooter string
 
-	Output         string
-	OutputJSON     bool
 	OutputBaseline bool
+	AnnotateBlame  bool
+
+	CodeOwners string
+	Owner      string
+	GroupBy    string
 
 	Debug              bool
 	DebugParseDuration time.Duration
@@ -52,6 +64,7 @@ type ParsedFlags struct {
 
 	Baseline             string
 	ConservativeBaseline bool
+	BaselineReportStale  bool
 
 	MisspellList string
 
@@ -76,8 +89,19 @@ type ParsedFlags struct {
 	GitDisableCompensateMaster bool
 	GitFullDiff                bool
 	GitIncludeUntracked        bool
+	GitStaged                  bool
 	GitRepo                    string
 
+	DiffFile string
+
+	// baselineUpdate is set by the baseline update command,
+	// it makes the linter rewrite the --baseline profile.
+	baselineUpdate bool
+
+	// rewrite is set by the rewrite command,
+	// it makes the linter apply the pattern rule fixes until a fixpoint.
+	rewrite bool
+
 	// These two flags are mutated in prepareGitArgs.
 	// This is bad, but it's easier for now than to fix this
 	// without introducing other issues.
@@ -197,9 +221,36 @@ func RegisterCheckFlags(ctx *AppContext) (*flag.FlagSet, *FlagsGroups) {
 	// Output group.
 	fs.StringVar(&ctx.ParsedFlags.Output, "output", "", "Output reports to a specified file instead of stderr")
 	fs.BoolVar(&ctx.ParsedFlags.OutputJSON, "output-json", false, "Format output as JSON")
+	fs.StringVar(&ctx.ParsedFlags.OutputFormat, "output-format", "text",
+		"Reports output format: text, json, checkstyle, junit, gitlab, github, html or template")
+	fs.StringVar(&ctx.ParsedFlags.OutputTemplate, "output-template", "",
+		"Go text/template that is executed for every report, e.g. '{{.RelPath}}:{{.Line}}:{{.Column}}: [{{.CheckName}}] {{.Message}}'")
+	fs.StringVar(&ctx.ParsedFlags.OutputTemplateHeader, "output-template-header", "",
+		"Go text/template that is executed before the reports, e.g. 'Found {{.Total}} issues'")
+	fs.StringVar(&ctx.ParsedFlags.OutputTemplateFooter, "output-template-footer", "",
+		"Go text/template that is executed after the reports")
+
+	fs.BoolVar(&ctx.ParsedFlags.AnnotateBlame, "annotate-blame", false,
+		"Add the author, commit and date of the last line change to JSON reports and print a per-author summary (requires git)")
 
 	groups.Add("Output", "output")
 	groups.Add("Output", "output-json")
+	groups.Add("Output", "output-format")
+	groups.Add("Output", "output-template")
+	groups.Add("Output", "output-template-header")
+	groups.Add("Output", "output-template-footer")
+	groups.Add("Output", "annotate-blame")
+
+	fs.StringVar(&ctx.ParsedFlags.CodeOwners, "codeowners", "",
+		"Path to a CODEOWNERS file used to add owners to reports (by default, it's searched in the current directory, .github, .gitlab and docs)")
+	fs.StringVar(&ctx.ParsedFlags.Owner, "owner", "",
+		"Comma-separated list of owners from the CODEOWNERS file, only their reports are shown (e.g. @team)")
+	fs.StringVar(&ctx.ParsedFlags.GroupBy, "group-by", "",
+		"Print a summary with the number of reports per group; the only supported group is owner")
+
+	groups.Add("Output", "codeowners")
+	groups.Add("Output", "owner")
+	groups.Add("Output", "group-by")
 
 	// Git group.
 	fs.BoolVar(&ctx.ParsedFlags.Gitignore, "gitignore", false,
@@ -214,9 +265,15 @@ func RegisterCheckFlags(ctx *AppContext) (*flag.FlagSet, *FlagsGroups) {
 	fs.BoolVar(&ctx.ParsedFlags.GitSkipFetch, "git-skip-fetch", false, "Do not fetch ORIGIN_MASTER (use this option if you already fetch to ORIGIN_MASTER before that)")
 	fs.BoolVar(&ctx.ParsedFlags.GitDisableCompensateMaster, "git-disable-compensate-master", false, "Do not try to compensate for changes in ORIGIN_MASTER after branch point")
 	fs.BoolVar(&ctx.ParsedFlags.GitFullDiff, "git-full-diff", false, "Compute full diff, in which linter analyzes all files, not just changed ones")
+	fs.BoolVar(&ctx.ParsedFlags.GitStaged, "git-staged", false,
+		"Analyze the staged changes against HEAD, reading files from the index (use it in pre-commit hooks)")
+	fs.StringVar(&ctx.ParsedFlags.DiffFile, "diff-file", "",
+		"Path to a unified diff file (- for stdin) that is already applied to the files; only warnings on the added lines are reported")
 
 	groups.Add("Diff mode", "git")
+	groups.Add("Diff mode", "diff-file")
 	groups.Add("Diff mode", "git-full-diff")
+	groups.Add("Diff mode", "git-staged")
 	groups.Add("Diff mode", "git-commit-from")
 	groups.Add("Diff mode", "git-commit-to")
 	groups.Add("Diff mode", "git-author-whitelist")
@@ -233,15 +290,21 @@ func RegisterCheckFlags(ctx *AppContext) (*flag.FlagSet, *FlagsGroups) {
 	fs.BoolVar(&ctx.ParsedFlags.ConservativeBaseline, "conservative-baseline", false,
 		"Use a conservative baseline mode in which it will have less false positive, but more false negatives")
 	fs.BoolVar(&ctx.ParsedFlags.OutputBaseline, "output-baseline", false, "Output a suppression profile instead of reports")
+	fs.BoolVar(&ctx.ParsedFlags.BaselineReportStale, "baseline-report-stale", false,
+		"Print suppressions from the --baseline profile that no longer match any report")
 
 	groups.Add("Baseline", "baseline")
 	groups.Add("Baseline", "conservative-baseline")
 	groups.Add("Baseline", "output-baseline")
+	groups.Add("Baseline", "baseline-report-stale")
 
 	// Dynamic rules group.
 	fs.StringVar(&ctx.ParsedFlags.RulesList, "rules", "",
 		"Comma-separated list of files or folders with dynamic rules")
+	fs.StringVar(&ctx.ParsedFlags.RulePacks, "rule-packs", "",
+		"Comma-separated list of embedded rule packs to enable, run 'noverify checkers' to see the available packs")
 	groups.Add("Dynamic rules", "rules")
+	groups.Add("Dynamic rules", "rule-packs")
 
 	// Debug group.
 	fs.StringVar(&ctx.ParsedFlags.PprofHost, "pprof", "", "HTTP pprof endpoint (e.g. localhost:8080)")
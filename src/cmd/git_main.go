@@ -156,7 +156,74 @@ func gitRepoComputeReportsFromLocalChanges(l *LinterRunner) (oldReports, reports
 	return oldReports, reports, changes, true
 }
 
+func gitRepoComputeReportsFromStagedChanges(l *LinterRunner) (oldReports, reports []*linter.Report, changes []git.Change) {
+	// TODO(quasilyte): hard to replace fatalf with error return here. Use panicf for now.
+
+	// compute changes between HEAD and the index, unstaged changes are ignored
+	changes, err := git.Diff(l.flags.GitRepo, "", []string{"--cached", "HEAD"})
+	if err != nil {
+		log.Panicf("Could not compute git diff: %s", err.Error())
+	}
+
+	if len(changes) == 0 {
+		return nil, nil, nil
+	}
+
+	start := time.Now()
+	l.linter.AnalyzeFiles(workspace.ReadFilesFromGit(l.flags.GitRepo, "HEAD", nil, l.config.PhpExtensions))
+	parseIndexOnlyFiles(l)
+	log.Printf("Indexing complete in %s", time.Since(start))
+
+	l.linter.MetaInfo().SetIndexingComplete(true)
+
+	start = time.Now()
+	oldReports = l.linter.AnalyzeFiles(workspace.ReadOldFilesFromGit(l.flags.GitRepo, "HEAD", changes, l.config.PhpExtensions))
+	log.Printf("Parsed old files versions for %s", time.Since(start))
+
+	start = time.Now()
+	l.linter.MetaInfo().SetIndexingComplete(false)
+	l.linter.AnalyzeFiles(workspace.ReadStagedChanges(l.flags.GitRepo, changes, l.config.PhpExtensions))
+	parseIndexOnlyFiles(l)
+	l.linter.MetaInfo().SetIndexingComplete(true)
+	log.Printf("Indexed staged files versions for %s", time.Since(start))
+
+	start = time.Now()
+	reports = l.linter.AnalyzeFiles(workspace.ReadStagedChanges(l.flags.GitRepo, changes, l.config.PhpExtensions))
+	log.Printf("Parsed staged file versions in %s", time.Since(start))
+
+	return oldReports, reports, changes
+}
+
+// gitStagedMain analyzes the staged changes, so the pre-commit hook
+// checks exactly what is going to be committed.
+func gitStagedMain(runner *LinterRunner, ctx *AppContext) (status int, err error) {
+	oldReports, reports, changes := gitRepoComputeReportsFromStagedChanges(runner)
+	if len(changes) == 0 {
+		log.Printf("No staged changes")
+		return 0, nil
+	}
+
+	start := time.Now()
+	diff, err := linter.DiffReports(runner.flags.GitRepo, nil, changes, nil, oldReports, reports, 8)
+	if err != nil {
+		return 0, fmt.Errorf("Could not compute reports diff: %v", err)
+	}
+	log.Printf("Computed reports diff for %s", time.Since(start))
+
+	stat, err := processReports(runner, ctx.MainConfig, diff)
+	if err != nil {
+		return 1, err
+	}
+	status = processReportsStat(ctx, stat)
+
+	return status, nil
+}
+
 func gitMain(runner *LinterRunner, ctx *AppContext) (status int, err error) {
+	if runner.flags.GitStaged {
+		return gitStagedMain(runner, ctx)
+	}
+
 	var (
 		oldReports, reports []*linter.Report
 		diffArgs            []string
@@ -185,7 +252,10 @@ func gitMain(runner *LinterRunner, ctx *AppContext) (status int, err error) {
 	}
 	log.Printf("Computed reports diff for %s", time.Since(start))
 
-	stat := processReports(runner, ctx.MainConfig, diff)
+	stat, err := processReports(runner, ctx.MainConfig, diff)
+	if err != nil {
+		return 1, err
+	}
 	status = processReportsStat(ctx, stat)
 
 	return status, nil
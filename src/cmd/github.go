@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+)
+
+var (
+	githubDataEscaper = strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+	)
+	githubPropertyEscaper = strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+		":", "%3A",
+		",", "%2C",
+	)
+)
+
+// writeGitHubReports writes the reports as the GitHub Actions workflow commands,
+// so they're shown as annotations in the pull requests.
+func writeGitHubReports(w io.Writer, runner *LinterRunner, reports []*linter.Report) error {
+	for _, r := range reports {
+		msg := r.Message
+		if r.CheckName != "" {
+			msg = r.CheckName + ": " + msg
+		}
+		filename := baseline.RelativePath(runner.projectRoot, r.Filename)
+
+		_, err := fmt.Fprintf(w, "::%s file=%s,line=%d,col=%d::%s\n",
+			githubCommand(runner, r),
+			githubPropertyEscaper.Replace(filename),
+			r.Line,
+			r.StartChar+1,
+			githubDataEscaper.Replace(msg))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubCommand(runner *LinterRunner, r *linter.Report) string {
+	if runner.checkersFilter.IsCriticalReport(r) {
+		return "error"
+	}
+	if r.Level == lintapi.LevelNotice {
+		return "notice"
+	}
+	return "warning"
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+)
+
+func TestGitHubEscapers(t *testing.T) {
+	tests := []struct {
+		in       string
+		data     string
+		property string
+	}{
+		{in: "plain text", data: "plain text", property: "plain text"},
+		{in: "100%", data: "100%25", property: "100%25"},
+		{in: "%0A", data: "%250A", property: "%250A"},
+		{in: "a\r\nb", data: "a%0D%0Ab", property: "a%0D%0Ab"},
+		{in: "a:b,c", data: "a:b,c", property: "a%3Ab%2Cc"},
+		{in: "::error file=x::", data: "::error file=x::", property: "%3A%3Aerror file=x%3A%3A"},
+	}
+
+	for _, test := range tests {
+		if have := githubDataEscaper.Replace(test.in); have != test.data {
+			t.Errorf("data %q: have %q, want %q", test.in, have, test.data)
+		}
+		if have := githubPropertyEscaper.Replace(test.in); have != test.property {
+			t.Errorf("property %q: have %q, want %q", test.in, have, test.property)
+		}
+	}
+}
+
+func TestGitHubCommand(t *testing.T) {
+	runner, _ := newOutputTestRunner()
+
+	tests := []struct {
+		checkName string
+		level     int
+		want      string
+	}{
+		{checkName: "undefinedClass", level: lintapi.LevelError, want: "error"},
+		{checkName: "undefinedClass", level: lintapi.LevelNotice, want: "error"},
+		{checkName: "unused", level: lintapi.LevelError, want: "warning"},
+		{checkName: "unused", level: lintapi.LevelWarning, want: "warning"},
+		{checkName: "unused", level: lintapi.LevelSecurity, want: "warning"},
+		{checkName: "unused", level: lintapi.LevelNotice, want: "notice"},
+	}
+
+	for _, test := range tests {
+		r := &linter.Report{CheckName: test.checkName, Level: test.level}
+		if have := githubCommand(runner, r); have != test.want {
+			t.Errorf("%s with level %d: have %s, want %s", test.checkName, test.level, have, test.want)
+		}
+	}
+}
+
+func TestGitHubReports(t *testing.T) {
+	runner, reports := newOutputTestRunner()
+	reports[0].Message = "Variable $x is unused\n100% sure"
+
+	want := "::warning file=src/b.php,line=1,col=3::unused: Variable $x is unused%0A100%25 sure\n" +
+		"::error file=src/a.php,line=10,col=5::undefinedClass: Class \\A\\B not found\n" +
+		"::notice file=src/a.php,line=3,col=1::strictCmp: Maybe use <b> & \"c\" instead\n" +
+		"::warning file=/outside/c.php,line=2,col=1::unused: Variable $y is unused\n"
+
+	var buf bytes.Buffer
+	if err := writeGitHubReports(&buf, runner, reports); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if buf.String() != want {
+		t.Errorf("unexpected output:\nhave:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+)
+
+// gitlabIssue is the subset of the Code Climate issue
+// that is used by the GitLab Code Quality reports.
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// writeGitLabReports writes the reports in the GitLab Code Quality format.
+//
+// The fingerprint is computed from the report hash, so it
+// doesn't change when the lines are moved inside the file.
+func writeGitLabReports(w io.Writer, runner *LinterRunner, reports []*linter.Report) error {
+	issues := make([]gitlabIssue, 0, len(reports))
+	seen := make(map[string]int)
+	for _, r := range reports {
+		filename := baseline.RelativePath(runner.projectRoot, r.Filename)
+
+		// Identical reports in the same file have the same hash,
+		// but GitLab requires the fingerprints to be unique.
+		key := filename + ":" + baseline.FormatHash(r.Hash)
+		seen[key]++
+		fingerprint := md5.Sum([]byte(fmt.Sprintf("%s:%d", key, seen[key])))
+
+		issues = append(issues, gitlabIssue{
+			Description: r.Message,
+			CheckName:   r.CheckName,
+			Fingerprint: hex.EncodeToString(fingerprint[:]),
+			Severity:    gitlabSeverity(runner, r),
+			Location: gitlabLocation{
+				Path:  filename,
+				Lines: gitlabLines{Begin: r.Line},
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
+
+// gitlabSeverity maps the report level to the Code Climate severity.
+// Critical reports are never less than major.
+func gitlabSeverity(runner *LinterRunner, r *linter.Report) string {
+	if runner.checkersFilter.IsCriticalReport(r) {
+		switch r.Level {
+		case lintapi.LevelError, lintapi.LevelSecurity:
+			return "critical"
+		default:
+			return "major"
+		}
+	}
+	if r.Level == lintapi.LevelNotice {
+		return "info"
+	}
+	return "minor"
+}
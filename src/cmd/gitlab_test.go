@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+)
+
+func TestGitLabSeverity(t *testing.T) {
+	runner, _ := newOutputTestRunner()
+
+	tests := []struct {
+		checkName string
+		level     int
+		want      string
+	}{
+		{checkName: "undefinedClass", level: lintapi.LevelError, want: "critical"},
+		{checkName: "undefinedClass", level: lintapi.LevelSecurity, want: "critical"},
+		{checkName: "undefinedClass", level: lintapi.LevelWarning, want: "major"},
+		{checkName: "undefinedClass", level: lintapi.LevelNotice, want: "major"},
+		{checkName: "unused", level: lintapi.LevelError, want: "minor"},
+		{checkName: "unused", level: lintapi.LevelWarning, want: "minor"},
+		{checkName: "unused", level: lintapi.LevelNotice, want: "info"},
+	}
+
+	for _, test := range tests {
+		r := &linter.Report{CheckName: test.checkName, Level: test.level}
+		if have := gitlabSeverity(runner, r); have != test.want {
+			t.Errorf("%s with level %d: have %s, want %s", test.checkName, test.level, have, test.want)
+		}
+	}
+}
+
+func TestGitLabFingerprints(t *testing.T) {
+	runner, _ := newOutputTestRunner()
+
+	fingerprints := func(reports ...*linter.Report) []string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := writeGitLabReports(&buf, runner, reports); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		var issues []gitlabIssue
+		if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		res := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			res = append(res, issue.Fingerprint)
+		}
+		return res
+	}
+	report := func(line int, hash uint64) *linter.Report {
+		return &linter.Report{
+			CheckName: "unused",
+			Level:     lintapi.LevelWarning,
+			Message:   "Variable $x is unused",
+			Filename:  filepath.Join(runner.projectRoot, "src", "a.php"),
+			Line:      line,
+			Hash:      hash,
+		}
+	}
+
+	// The fingerprint only depends on the relative path, the hash and the
+	// number of the identical reports before, so it must not change between versions.
+	want := fingerprints(report(1, 0x1234), report(2, 0x1234), report(3, 0x5678))
+	if want[0] == want[1] || want[0] == want[2] || want[1] == want[2] {
+		t.Errorf("fingerprints are not unique: %v", want)
+	}
+	// md5("src/a.php:3lg:1"), where 3lg is the base36 report hash.
+	if want[0] != "bf27cc0cfddb74037c2848324c81db2e" {
+		t.Errorf("fingerprint of the first report is changed: %s", want[0])
+	}
+
+	// Moved lines keep the fingerprints.
+	moved := fingerprints(report(10, 0x1234), report(20, 0x1234), report(30, 0x5678))
+	if diff := cmp.Diff(want, moved); diff != "" {
+		t.Errorf("fingerprints of the moved reports are changed: %s", diff)
+	}
+
+	// The project root doesn't affect the fingerprints.
+	runner.projectRoot = filepath.Join(string(filepath.Separator)+"other", "checkout")
+	other := fingerprints(report(1, 0x1234), report(2, 0x1234), report(3, 0x5678))
+	if diff := cmp.Diff(want, other); diff != "" {
+		t.Errorf("fingerprints in another checkout are changed: %s", diff)
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/VKCOM/noverify/src/rules"
+)
+
+// grepCheckName is a name of the rule that is created from the grep pattern.
+const grepCheckName = "grep"
+
+var patternErrorLineRegexp = regexp.MustCompile(`^pattern:\d+: `)
+
+// grepOutputTemplate is the default grep output template,
+// it prints the matched line and all named captures.
+const grepOutputTemplate = `{{.RelPath}}:{{.Line}}: {{.Context}}` +
+	`{{range $name, $text := .Captures}}{{"\n"}}    ${{$name}} = {{$text}}{{end}}`
+
+// PatternFlags are the pattern constraints of the grep and rewrite commands.
+// They have the same syntax as the dynamic rule attributes.
+type PatternFlags struct {
+	Types        stringListFlag
+	Pure         stringListFlag
+	Filters      stringListFlag
+	StrictSyntax bool
+}
+
+// stringListFlag is a flag that can be passed several times.
+type stringListFlag []string
+
+func (l *stringListFlag) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringListFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func registerPatternFlags(fs *flag.FlagSet, groups *FlagsGroups, flags *PatternFlags) {
+	groups.AddGroup("Pattern")
+
+	fs.Var(&flags.Types, "type",
+		"Type constraint in the '<type> $var' form, like the @type rule attribute; can be used several times")
+	fs.Var(&flags.Pure, "pure",
+		"Variable that should be side effect free, like the @pure rule attribute; can be used several times")
+	fs.Var(&flags.Filters, "filter",
+		"Regexp constraint in the '$var <regexp>' form, like the @filter rule attribute; can be used several times")
+	fs.BoolVar(&flags.StrictSyntax, "strict-syntax", false,
+		"Disable the fuzzy matching, like the @strict-syntax rule attribute")
+
+	groups.Add("Pattern", "type")
+	groups.Add("Pattern", "pure")
+	groups.Add("Pattern", "filter")
+	groups.Add("Pattern", "strict-syntax")
+}
+
+func RegisterGrepFlags(ctx *AppContext) (*flag.FlagSet, *FlagsGroups) {
+	fs, groups := RegisterCheckFlags(ctx)
+	flags := &PatternFlags{}
+	registerPatternFlags(fs, groups, flags)
+	ctx.CustomFlags = flags
+	return fs, groups
+}
+
+// Grep prints all code fragments matching the phpgrep pattern.
+//
+// The pattern is turned into a dynamic rule, so the files are analyzed
+// in the same way as by the check command and all rule constraints work.
+func Grep(ctx *AppContext) (int, error) {
+	if len(ctx.ParsedArgs) == 0 {
+		return 1, fmt.Errorf("pattern is not specified")
+	}
+	pattern := ctx.ParsedArgs[0]
+	ctx.ParsedArgs = ctx.ParsedArgs[1:]
+	if len(ctx.ParsedArgs) == 0 {
+		ctx.ParsedArgs = []string{"."}
+	}
+
+	rset, err := parsePatternRule(grepCheckName, pattern, "", ctx.CustomFlags.(*PatternFlags))
+	if err != nil {
+		return 1, err
+	}
+	addPatternRule(ctx, rset)
+
+	config := ctx.MainConfig.linter.Config()
+	config.CollectRuleCaptures = true
+
+	flags := &ctx.ParsedFlags
+	if flags.Output == "" {
+		flags.Output = "stdout"
+	}
+	if !flags.OutputJSON && flags.OutputFormat == "text" && flags.OutputTemplate == "" {
+		flags.OutputTemplate = grepOutputTemplate
+	}
+	ctx.MainConfig.DisableAfterReportsLog = true
+
+	return Check(ctx)
+}
+
+// addPatternRule makes the pattern rule the only enabled check.
+func addPatternRule(ctx *AppContext, rset *rules.Set) {
+	ctx.MainConfig.linter.Config().Checkers.DeclareRules(rset)
+	ctx.MainConfig.rulesSets = append(ctx.MainConfig.rulesSets, rset)
+	ctx.ParsedFlags.AllowChecks = strings.Join(rset.Names, ",")
+}
+
+// parsePatternRule creates a rule set with a single rule from the pattern.
+// If fix is not empty, it's used as a rule quick fix template.
+func parsePatternRule(name, pattern, fix string, flags *PatternFlags) (*rules.Set, error) {
+	pattern = strings.TrimSpace(pattern)
+	if !strings.HasSuffix(pattern, ";") && !strings.HasSuffix(pattern, "}") {
+		pattern += ";"
+	}
+
+	var src strings.Builder
+	src.WriteString("<?php\n/**\n")
+	fmt.Fprintf(&src, " * @name %s\n", name)
+	src.WriteString(" * @maybe Matched the pattern\n")
+	src.WriteString(" * @scope any\n")
+	if fix != "" {
+		fmt.Fprintf(&src, " * @fix %s\n", fix)
+	}
+	if flags.StrictSyntax {
+		src.WriteString(" * @strict-syntax\n")
+	}
+	for _, typ := range flags.Types {
+		fmt.Fprintf(&src, " * @type %s\n", typ)
+	}
+	for _, name := range flags.Pure {
+		fmt.Fprintf(&src, " * @pure %s\n", name)
+	}
+	for _, filter := range flags.Filters {
+		fmt.Fprintf(&src, " * @filter %s\n", filter)
+	}
+	src.WriteString(" */\n")
+	src.WriteString(pattern)
+	src.WriteString("\n")
+
+	rset, err := rules.NewParser().Parse("pattern", strings.NewReader(src.String()))
+	if err != nil {
+		// Line numbers of the generated rule file are meaningless for the user.
+		return nil, fmt.Errorf("pattern: %s", patternErrorLineRegexp.ReplaceAllString(err.Error(), ""))
+	}
+	return rset, nil
+}
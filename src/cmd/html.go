@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"html/template"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/lintdoc"
+	"github.com/VKCOM/noverify/src/linter"
+)
+
+type htmlPage struct {
+	Total    int
+	Critical int
+	Checks   []htmlGroup
+	Dirs     []htmlGroup
+	Reports  []htmlReport
+	Docs     []htmlCheckerDoc
+}
+
+type htmlGroup struct {
+	Name  string
+	Count int
+}
+
+type htmlReport struct {
+	CheckName string
+	Severity  string
+	Critical  bool
+	Message   string
+	Filename  string
+	Dir       string
+	Line      int
+
+	// Context line split by the reported range.
+	Before    string
+	Highlight string
+	After     string
+}
+
+type htmlCheckerDoc struct {
+	Name string
+	Doc  string
+}
+
+// writeHTMLReports writes the self-contained HTML page with the reports
+// and the documentation of the reported checkers.
+//
+// Filtering, sorting and grouping are done by the inline script,
+// so the page doesn't need any external resources.
+func writeHTMLReports(w io.Writer, runner *LinterRunner, reports []*linter.Report) error {
+	page := htmlPage{Total: len(reports)}
+
+	checkCounts := make(map[string]int)
+	dirCounts := make(map[string]int)
+	for _, r := range reports {
+		filename := baseline.RelativePath(runner.projectRoot, r.Filename)
+		critical := runner.checkersFilter.IsCriticalReport(r)
+		if critical {
+			page.Critical++
+		}
+
+		before, highlight, after := splitReportContext(r)
+		report := htmlReport{
+			CheckName: r.CheckName,
+			Severity:  r.Severity(),
+			Critical:  critical,
+			Message:   r.Message,
+			Filename:  filename,
+			Dir:       path.Dir(filename),
+			Line:      r.Line,
+			Before:    before,
+			Highlight: highlight,
+			After:     after,
+		}
+		page.Reports = append(page.Reports, report)
+		checkCounts[report.CheckName]++
+		dirCounts[report.Dir]++
+	}
+
+	sort.SliceStable(page.Reports, func(i, j int) bool {
+		a, b := page.Reports[i], page.Reports[j]
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Line < b.Line
+	})
+	page.Checks = sortedHTMLGroups(checkCounts)
+	page.Dirs = sortedHTMLGroups(dirCounts)
+
+	for _, info := range runner.config.Checkers.ListDeclared() {
+		if checkCounts[info.Name] == 0 {
+			continue
+		}
+		var doc strings.Builder
+		if err := lintdoc.RenderCheckDocumentation(&doc, info); err != nil {
+			return err
+		}
+		page.Docs = append(page.Docs, htmlCheckerDoc{Name: info.Name, Doc: doc.String()})
+	}
+	sort.Slice(page.Docs, func(i, j int) bool {
+		return page.Docs[i].Name < page.Docs[j].Name
+	})
+
+	return htmlReportTemplate.Execute(w, page)
+}
+
+// splitReportContext splits the context line into the parts
+// before, inside and after the reported range.
+func splitReportContext(r *linter.Report) (before, highlight, after string) {
+	line := r.Context
+	start, end := r.StartChar, r.EndChar
+	if start < 0 || start > len(line) {
+		return line, "", ""
+	}
+	if end < start || end > len(line) {
+		end = len(line)
+	}
+	return line[:start], line[start:end], line[end:]
+}
+
+// sortedHTMLGroups returns the groups sorted by count in descending order.
+func sortedHTMLGroups(counts map[string]int) []htmlGroup {
+	groups := make([]htmlGroup, 0, len(counts))
+	for name, count := range counts {
+		groups = append(groups, htmlGroup{Name: name, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+var htmlReportTemplate = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>NoVerify report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+#reports th { cursor: pointer; user-select: none; }
+.groups { display: flex; gap: 2em; }
+.groups a { cursor: pointer; color: #0366d6; }
+.critical { color: #b00020; font-weight: bold; }
+.group-row td { background: #eef; font-weight: bold; }
+pre { margin: 0; white-space: pre-wrap; }
+pre.code { background: #f8f8f8; padding: 4px; }
+mark { background: #ffd6d6; }
+#filters { margin-bottom: 1em; }
+#filters > * { margin-right: 1em; }
+details pre { background: #f8f8f8; padding: 8px; }
+</style>
+</head>
+<body>
+<h1>NoVerify report</h1>
+<p>Found {{.Total}} issues, {{.Critical}} of them are critical.</p>
+
+<div class="groups">
+<table>
+<tr><th>Check</th><th>Issues</th></tr>
+{{- range .Checks}}
+<tr><td><a data-filter="check" data-value="{{.Name}}">{{.Name}}</a></td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+<table>
+<tr><th>Directory</th><th>Issues</th></tr>
+{{- range .Dirs}}
+<tr><td><a data-filter="dir" data-value="{{.Name}}">{{.Name}}</a></td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+</div>
+
+<div id="filters">
+<input id="filter-text" type="search" placeholder="Filter by text">
+<select id="filter-check"><option value="">All checks</option>
+{{- range .Checks}}<option>{{.Name}}</option>{{end -}}
+</select>
+<select id="filter-dir"><option value="">All directories</option>
+{{- range .Dirs}}<option>{{.Name}}</option>{{end -}}
+</select>
+<label><input id="filter-critical" type="checkbox"> Only critical</label>
+<label>Group by <select id="group-by">
+<option value="">nothing</option>
+<option value="check">check</option>
+<option value="dir">directory</option>
+</select></label>
+</div>
+
+<table id="reports">
+<thead><tr>
+<th data-sort="severity">Severity</th>
+<th data-sort="check">Check</th>
+<th data-sort="file">Location</th>
+<th>Message</th>
+</tr></thead>
+<tbody>
+{{- range .Reports}}
+<tr class="report" data-check="{{.CheckName}}" data-dir="{{.Dir}}" data-file="{{.Filename}}" data-line="{{.Line}}" data-severity="{{.Severity}}" data-critical="{{.Critical}}">
+<td{{if .Critical}} class="critical"{{end}}>{{.Severity}}</td>
+<td><a href="#doc-{{.CheckName}}">{{.CheckName}}</a></td>
+<td>{{.Filename}}:{{.Line}}</td>
+<td>{{.Message}}<pre class="code">{{.Before}}<mark>{{.Highlight}}</mark>{{.After}}</pre></td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+
+<h2>Checks documentation</h2>
+{{- range .Docs}}
+<details id="doc-{{.Name}}"><summary>{{.Name}}</summary><pre>{{.Doc}}</pre></details>
+{{- end}}
+
+<script>
+(function() {
+  var tbody = document.querySelector('#reports tbody');
+  var rows = Array.prototype.slice.call(tbody.querySelectorAll('tr.report'));
+  var text = document.getElementById('filter-text');
+  var check = document.getElementById('filter-check');
+  var dir = document.getElementById('filter-dir');
+  var critical = document.getElementById('filter-critical');
+  var groupBy = document.getElementById('group-by');
+  var sortKey = 'file';
+  var sortDesc = false;
+
+  function compare(a, b) {
+    var x = a.dataset[sortKey], y = b.dataset[sortKey];
+    var res = x < y ? -1 : x > y ? 1 : 0;
+    if (res === 0) {
+      res = a.dataset.file < b.dataset.file ? -1 : a.dataset.file > b.dataset.file ? 1 : 0;
+    }
+    if (res === 0) {
+      res = Number(a.dataset.line) - Number(b.dataset.line);
+    }
+    return sortDesc ? -res : res;
+  }
+
+  function visible(row) {
+    var q = text.value.toLowerCase();
+    if (q && row.textContent.toLowerCase().indexOf(q) < 0) return false;
+    if (check.value && row.dataset.check !== check.value) return false;
+    if (dir.value && row.dataset.dir !== dir.value) return false;
+    if (critical.checked && row.dataset.critical !== 'true') return false;
+    return true;
+  }
+
+  function render() {
+    var group = groupBy.value;
+    var list = rows.filter(visible).sort(function(a, b) {
+      if (group && a.dataset[group] !== b.dataset[group]) {
+        return a.dataset[group] < b.dataset[group] ? -1 : 1;
+      }
+      return compare(a, b);
+    });
+    tbody.innerHTML = '';
+    var current = null;
+    list.forEach(function(row) {
+      if (group && row.dataset[group] !== current) {
+        current = row.dataset[group];
+        var header = document.createElement('tr');
+        var cell = document.createElement('td');
+        header.className = 'group-row';
+        cell.colSpan = 4;
+        cell.textContent = current;
+        header.appendChild(cell);
+        tbody.appendChild(header);
+      }
+      tbody.appendChild(row);
+    });
+  }
+
+  [text, check, dir, critical, groupBy].forEach(function(el) {
+    el.addEventListener('input', render);
+    el.addEventListener('change', render);
+  });
+  document.querySelectorAll('#reports th[data-sort]').forEach(function(th) {
+    th.addEventListener('click', function() {
+      sortDesc = sortKey === th.dataset.sort ? !sortDesc : false;
+      sortKey = th.dataset.sort;
+      render();
+    });
+  });
+  document.querySelectorAll('.groups a').forEach(function(a) {
+    a.addEventListener('click', function() {
+      (a.dataset.filter === 'check' ? check : dir).value = a.dataset.value;
+      render();
+    });
+  });
+  render();
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHTMLReports(t *testing.T) {
+	runner, reports := newOutputTestRunner()
+	reports = reports[1:3]
+	reports[0].Message = `Class <script>alert("x")</script> not found`
+
+	var buf bytes.Buffer
+	if err := writeHTMLReports(&buf, runner, reports); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	page := buf.String()
+
+	// The page must be a well-formed document, so every tag is closed.
+	// The style and script contents are raw text in HTML, so they're skipped.
+	dec := xml.NewDecoder(strings.NewReader(stripHTMLRawText(t, page, "style", "script")))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+	var rows int
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("parse the page: %v", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "tr" {
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "class" && attr.Value == "report" {
+					rows++
+				}
+			}
+		}
+	}
+	if rows != 2 {
+		t.Errorf("report rows: have %d, want 2", rows)
+	}
+
+	mustContain := []string{
+		`Class &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; not found`,
+		`Maybe use &lt;b&gt; &amp; &#34;c&#34; instead`,
+		`data-file="src/a.php" data-line="3"`,
+		`data-file="src/a.php" data-line="10"`,
+	}
+	for _, s := range mustContain {
+		if !strings.Contains(page, s) {
+			t.Errorf("the page doesn't contain %s", s)
+		}
+	}
+	if strings.Contains(page, `<script>alert(`) {
+		t.Errorf("the page contains the unescaped message")
+	}
+}
+
+// stripHTMLRawText removes the contents of the raw text elements.
+func stripHTMLRawText(t *testing.T, page string, tags ...string) string {
+	t.Helper()
+	for _, tag := range tags {
+		open, end := "<"+tag+">", "</"+tag+">"
+		var res strings.Builder
+		for {
+			i := strings.Index(page, open)
+			if i < 0 {
+				break
+			}
+			j := strings.Index(page[i:], end)
+			if j < 0 {
+				t.Fatalf("unterminated %s", open)
+			}
+			res.WriteString(page[:i+len(open)])
+			page = page[i+j:]
+		}
+		res.WriteString(page)
+		page = res.String()
+	}
+	return page
+}
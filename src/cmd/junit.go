@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/linter"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Errors    []junitFailure `xml:"error"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReports writes the reports in the JUnit XML format.
+//
+// Every file with reports is a test case named by its path relative
+// to the project root, every report is a failure.
+// Critical reports are written as errors instead of failures.
+func writeJUnitReports(w io.Writer, runner *LinterRunner, reports []*linter.Report) error {
+	suite := junitTestSuite{Name: "noverify"}
+
+	filenames, byFile := groupReportsByFile(reports)
+	for _, filename := range filenames {
+		relPath := baseline.RelativePath(runner.projectRoot, filename)
+		testCase := junitTestCase{Name: relPath, ClassName: relPath}
+		for _, r := range byFile[filename] {
+			relReport := *r
+			relReport.Filename = relPath
+			failure := junitFailure{
+				Message: r.Message,
+				Type:    r.CheckName,
+				Text:    FormatReport(&relReport),
+			}
+			if runner.checkersFilter.IsCriticalReport(r) {
+				testCase.Errors = append(testCase.Errors, failure)
+				suite.Errors++
+			} else {
+				testCase.Failures = append(testCase.Failures, failure)
+				suite.Failures++
+			}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Tests = len(suite.Cases)
+
+	return writeXML(w, junitTestSuites{Suites: []junitTestSuite{suite}})
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -11,6 +12,7 @@ import (
 	"github.com/client9/misspell"
 
 	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/codeowners"
 	"github.com/VKCOM/noverify/src/lintdebug"
 	"github.com/VKCOM/noverify/src/linter"
 	"github.com/VKCOM/noverify/src/rules"
@@ -25,6 +27,21 @@ type LinterRunner struct {
 	checkersFilter *linter.CheckersFilter
 
 	outputFp io.Writer
+	// outputTemplate is set for the template output format.
+	outputTemplate *reportTemplate
+
+	// projectRoot is a directory the baseline and CODEOWNERS
+	// file paths are relative to, it's the working directory.
+	projectRoot string
+
+	// codeOwners is nil if there is no CODEOWNERS file.
+	codeOwners *codeowners.File
+	// ownersFilter is a set of owners from the --owner flag.
+	ownersFilter map[string]bool
+
+	// baselineProfile is set instead of the linter config profile when
+	// the baseline matching is done after the analysis, see needBaselineMatcher.
+	baselineProfile *baseline.Profile
 
 	filenameFilter *workspace.FilenameFilter
 }
@@ -76,9 +93,25 @@ func (l *LinterRunner) collectGitIgnoreFiles() error {
 	return nil
 }
 
+// findProjectRoot returns the directory the baseline
+// and CODEOWNERS file paths are relative to.
+func findProjectRoot() (string, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("getwd: %v", err)
+	}
+	return workingDir, nil
+}
+
 func (l *LinterRunner) Init(ruleSets []*rules.Set, flags *ParsedFlags) error {
 	l.flags = flags
 
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+	l.projectRoot = projectRoot
+
 	if err := l.collectGitIgnoreFiles(); err != nil {
 		return fmt.Errorf("collect gitignore files: %v", err)
 	}
@@ -103,7 +136,14 @@ func (l *LinterRunner) Init(ruleSets []*rules.Set, flags *ParsedFlags) error {
 
 	l.config.PhpExtensions = strings.Split(flags.PhpExtensionsArg, ",")
 
-	l.config.ComputeBaselineHashes = l.flags.Baseline != "" || l.flags.OutputBaseline
+	if err := l.initOutputFormat(); err != nil {
+		return err
+	}
+
+	// JSON reports include hashes, so they can be matched against the baseline later.
+	// GitLab reports use hashes as fingerprints.
+	l.config.ComputeBaselineHashes = l.flags.Baseline != "" || l.flags.OutputBaseline ||
+		l.flags.OutputFormat == "json" || l.flags.OutputFormat == "gitlab"
 
 	if flags.MisspellList != "" {
 		err := LoadMisspellDicts(l.config, strings.Split(flags.MisspellList, ","))
@@ -114,12 +154,18 @@ func (l *LinterRunner) Init(ruleSets []*rules.Set, flags *ParsedFlags) error {
 
 	l.checkersFilter = l.initCheckMappings(ruleSets)
 
+	l.config.UnusedParams = l.checkersFilter.IsEnabledCheck("unusedParam")
+	l.config.UnusedPrivateMembers = l.checkersFilter.IsEnabledCheck("unusedPrivateMember")
+
 	if err := l.initRules(ruleSets); err != nil {
 		return fmt.Errorf("rules: %v", err)
 	}
 	if err := l.initBaseline(); err != nil {
 		return fmt.Errorf("baseline: %v", err)
 	}
+	if err := l.initCodeOwners(); err != nil {
+		return fmt.Errorf("codeowners: %v", err)
+	}
 
 	l.linter.UseCheckersFilter(l.checkersFilter)
 
@@ -170,23 +216,93 @@ func (l *LinterRunner) addVendorFolderToFlag(flag string, regex bool) string {
 }
 
 func (l *LinterRunner) initBaseline() error {
+	l.config.BaselineRoot = l.projectRoot
+
 	if l.flags.Baseline == "" {
+		if l.needBaselineMatcher() {
+			return fmt.Errorf("--baseline flag is required")
+		}
 		return nil
 	}
 
-	f, err := os.Open(l.flags.Baseline)
+	profile, _, err := readProfileFile(l.flags.Baseline)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-	profile, _, err := baseline.ReadProfile(f)
+	if profile.BaseNameKeys && !l.flags.baselineUpdate {
+		log.Printf("%s uses the legacy format keyed by file base names, run 'noverify baseline update' to migrate it", l.flags.Baseline)
+	}
+
+	if l.needBaselineMatcher() {
+		l.baselineProfile = profile
+	} else {
+		l.config.BaselineProfile = profile
+	}
+	return nil
+}
+
+// needBaselineMatcher reports whether we need to know which suppressions
+// were used. In this case the reports are not suppressed by the linter,
+// they're matched against the profile after the analysis.
+func (l *LinterRunner) needBaselineMatcher() bool {
+	return l.flags.baselineUpdate || l.flags.BaselineReportStale
+}
+
+func (l *LinterRunner) initCodeOwners() error {
+	if l.flags.GroupBy != "" && l.flags.GroupBy != "owner" {
+		return fmt.Errorf("unsupported --group-by=%s, only owner is supported", l.flags.GroupBy)
+	}
+
+	// CODEOWNERS is only loaded on demand, so a malformed
+	// file doesn't break the runs that don't need it.
+	if l.flags.CodeOwners == "" && l.flags.Owner == "" && l.flags.GroupBy != "owner" {
+		return nil
+	}
+
+	filename := l.flags.CodeOwners
+	if filename == "" {
+		filename = codeowners.Find(l.projectRoot)
+	}
+	if filename == "" {
+		return fmt.Errorf("CODEOWNERS file is not found, use --codeowners to specify it")
+	}
+
+	f, err := codeowners.ParseFile(filename)
 	if err != nil {
 		return err
 	}
-	l.config.BaselineProfile = profile
+	l.codeOwners = f
+
+	if l.flags.Owner != "" {
+		l.ownersFilter = make(map[string]bool)
+		for _, owner := range strings.Split(l.flags.Owner, ",") {
+			l.ownersFilter[strings.TrimSpace(owner)] = true
+		}
+	}
+
 	return nil
 }
 
+// reportOwners fills the report owners and tells whether the report
+// passes the --owner filter.
+func (l *LinterRunner) reportOwners(r *linter.Report) bool {
+	if l.codeOwners == nil {
+		return true
+	}
+
+	r.Owners = l.codeOwners.Owners(baseline.RelativePath(l.projectRoot, r.Filename))
+
+	if l.ownersFilter == nil {
+		return true
+	}
+	for _, owner := range r.Owners {
+		if l.ownersFilter[owner] {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *LinterRunner) compileRegexes() error {
 	if l.flags.ReportsExclude != "" {
 		var err error
@@ -1,13 +1,11 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	_ "net/http/pprof" // it is ok for actually main package
 	"os"
-	"path/filepath"
 	"runtime"
 	"runtime/pprof"
 	"strings"
@@ -75,6 +73,147 @@ func registerMainApp() *App {
 					return 0, nil
 				},
 			},
+			{
+				Name:        "baseline",
+				Description: "The commands to manage the baseline suppression profile",
+				Action: func(ctx *AppContext) (int, error) {
+					ctx.App.showHelp()
+					return 0, nil
+				},
+				Commands: []*Command{
+					{
+						Name:        "stats",
+						Description: "The command to show the number of suppressed reports per check and per directory",
+						Action:      BaselineStats,
+						Arguments: []*Argument{
+							{
+								Name:        "baseline",
+								Description: "Baseline file",
+							},
+						},
+					},
+					{
+						Name:        "diff",
+						Description: "The command to show the suppressions added or removed between two baselines",
+						Action:      BaselineDiff,
+						Arguments: []*Argument{
+							{
+								Name:        "old",
+								Description: "Old baseline file",
+							},
+							{
+								Name:        "new",
+								Description: "New baseline file",
+							},
+						},
+						Examples: []Example{
+							{
+								Line:        "<(git show HEAD:baseline.json) baseline.json",
+								Description: "Shows the baseline changes that are not committed yet.",
+							},
+						},
+					},
+					{
+						Name:        "explain",
+						Description: "The command to show which reports are suppressed by the baseline",
+						Action:      BaselineExplain,
+						Arguments: []*Argument{
+							{
+								Name:        "baseline",
+								Description: "Baseline file",
+							},
+							{
+								Name:        "reports",
+								Description: "JSON report file created by --output-json",
+							},
+						},
+						Examples: []Example{
+							{
+								Line:        "baseline.json reports.json",
+								Description: "Prints the reports from 'reports.json' suppressed by 'baseline.json'.",
+							},
+						},
+					},
+					{
+						Name:        "update",
+						Description: "The command to remove the stale suppressions from the baseline",
+						Action:      BaselineUpdate,
+						Arguments: []*Argument{
+							{
+								Name:        "folders/files",
+								Description: "Folders and/or files for check",
+							},
+						},
+						RegisterFlags: RegisterCheckFlags,
+						Examples: []Example{
+							{
+								Line:        "--baseline=baseline.json ./lib",
+								Description: "Rewrites 'baseline.json' keeping only the suppressions that still match some report.",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:        "grep",
+				Description: "The command to search the code with a structural phpgrep pattern",
+				Action:      Grep,
+				Arguments: []*Argument{
+					{
+						Name:        "pattern",
+						Description: "Pattern in the dynamic rules syntax",
+					},
+					{
+						Name:        "folders/files",
+						Description: "Folders and/or files for search (current directory by default)",
+					},
+				},
+				RegisterFlags: RegisterGrepFlags,
+				Examples: []Example{
+					{
+						Line:        "'in_array($x, $arr)' ./src",
+						Description: "Prints all in_array calls with the captured arguments.",
+					},
+					{
+						Line:        "--type='string $x' '$x == $y' ./src",
+						Description: "Prints all == comparisons where the left operand is a string.",
+					},
+					{
+						Line:        "--output-json 'exit($_)' ./src",
+						Description: "Prints all exit calls in the JSON format.",
+					},
+				},
+			},
+			{
+				Name:        "rewrite",
+				Description: "The command to replace the code matching a structural phpgrep pattern",
+				Action:      Rewrite,
+				Arguments: []*Argument{
+					{
+						Name:        "pattern",
+						Description: "Pattern in the dynamic rules syntax",
+					},
+					{
+						Name:        "replacement",
+						Description: "Replacement in the @fix rule attribute syntax",
+					},
+					{
+						Name:        "folders/files",
+						Description: "Folders and/or files to rewrite (current directory by default)",
+					},
+				},
+				RegisterFlags: RegisterRewriteFlags,
+				Examples: []Example{
+					{
+						Line:        "--dry-run 'array_key_exists($k, $arr)' 'isset($arr[$k])' ./src",
+						Description: "Prints the unified diff of the replacements without changing the files.",
+					},
+					{
+						Line:        `--type='string $s' 'strlen($s) == 0' "\$s === ''" ./src`,
+						Description: "Replaces the empty string checks only for the string arguments.",
+					},
+				},
+			},
 			{
 				Name:          "test-rules",
 				Description:   "The command to test the dynamic rules",
@@ -122,6 +261,22 @@ func Run(cfg *MainConfig) (int, error) {
 
 	cfg.rulesSets = append(cfg.rulesSets, ruleSets...)
 
+	for _, rset := range cfg.RuleSets {
+		if err := declareRules(config.Checkers, rset); err != nil {
+			return 1, err
+		}
+	}
+
+	cfg.rulesSets = append(cfg.rulesSets, cfg.RuleSets...)
+
+	// Rule packs are only declared when they are enabled, so their
+	// rule names don't conflict with the user rules otherwise.
+	rulePacks, err := ParseEmbeddedRulePacks()
+	if err != nil {
+		return 1, fmt.Errorf("preload rule packs: %v", err)
+	}
+	cfg.rulePacks = rulePacks
+
 	if cfg.RegisterCheckers != nil {
 		for _, checker := range cfg.RegisterCheckers() {
 			cfg.linter.Config().Checkers.DeclareChecker(checker)
@@ -150,6 +305,10 @@ func Main(cfg *MainConfig) {
 //
 // We don't want os.Exit to be inserted randomly to avoid defer cancellation.
 func mainNoExit(ctx *AppContext) (status int, err error) {
+	if err := checkModeFlags(&ctx.ParsedFlags); err != nil {
+		return 1, err
+	}
+
 	if ctx.ParsedFlags.PprofHost != "" {
 		go func() {
 			err := http.ListenAndServe(ctx.ParsedFlags.PprofHost, nil)
@@ -213,6 +372,12 @@ func mainNoExit(ctx *AppContext) (status int, err error) {
 	if ctx.ParsedFlags.GitRepo != "" {
 		return gitMain(runner, ctx)
 	}
+	if ctx.ParsedFlags.DiffFile != "" {
+		return diffFileMain(runner, ctx)
+	}
+	if ctx.ParsedFlags.rewrite {
+		return rewriteMain(runner, ctx)
+	}
 
 	filenames := ctx.ParsedArgs
 
@@ -235,12 +400,59 @@ func mainNoExit(ctx *AppContext) (status int, err error) {
 		return 0, nil
 	}
 
-	stat := processReports(runner, ctx.MainConfig, reports)
+	if runner.flags.baselineUpdate {
+		if err := updateBaseline(runner, ctx.MainConfig, reports); err != nil {
+			return 1, fmt.Errorf("update baseline: %v", err)
+		}
+		return 0, nil
+	}
+
+	if runner.flags.BaselineReportStale {
+		reports = reportStaleBaseline(runner, reports)
+	}
+
+	stat, err := processReports(runner, ctx.MainConfig, reports)
+	if err != nil {
+		return 1, err
+	}
 	status = processReportsStat(ctx, stat)
 
 	return status, nil
 }
 
+// checkModeFlags reports the flag combinations that select
+// several mutually exclusive run modes.
+func checkModeFlags(flags *ParsedFlags) error {
+	if flags.GitStaged && flags.GitRepo == "" {
+		return fmt.Errorf("--git-staged requires --git")
+	}
+	if flags.GitRepo != "" && flags.DiffFile != "" {
+		return fmt.Errorf("--git and --diff-file can't be used together")
+	}
+	if flags.rewrite && (flags.GitRepo != "" || flags.DiffFile != "") {
+		return fmt.Errorf("rewrite can't be used with --git or --diff-file")
+	}
+	if flags.GitRepo != "" {
+		switch {
+		case flags.baselineUpdate:
+			return fmt.Errorf("baseline update can't be used with --git")
+		case flags.BaselineReportStale:
+			return fmt.Errorf("--baseline-report-stale can't be used with --git")
+		}
+	}
+	if flags.DiffFile != "" {
+		switch {
+		case flags.OutputBaseline:
+			return fmt.Errorf("--output-baseline can't be used with --diff-file")
+		case flags.baselineUpdate:
+			return fmt.Errorf("baseline update can't be used with --diff-file")
+		case flags.BaselineReportStale:
+			return fmt.Errorf("--baseline-report-stale can't be used with --diff-file")
+		}
+	}
+	return nil
+}
+
 func processReportsStat(ctx *AppContext, stat ReportsStat) (status int) {
 	if stat.critical > 0 {
 		status = 2
@@ -288,7 +500,7 @@ func createBaseline(l *LinterRunner, cfg *MainConfig, reports []*linter.Report)
 
 		stats.CountTotal++
 		stats.CountPerCheck[r.CheckName]++
-		filename := filepath.Base(r.Filename)
+		filename := baseline.RelativePath(l.projectRoot, r.Filename)
 		f, ok := files[filename]
 		if !ok {
 			f.Filename = filename
@@ -348,13 +560,16 @@ type ReportsStat struct {
 	autofixable int
 }
 
-func processReports(runner *LinterRunner, cfg *MainConfig, diff []*linter.Report) (stat ReportsStat) {
+func processReports(runner *LinterRunner, cfg *MainConfig, diff []*linter.Report) (stat ReportsStat, err error) {
 	filtered := make([]*linter.Report, 0, len(diff))
 
 	for _, r := range diff {
 		if cfg.BeforeReport != nil && !cfg.BeforeReport(r) {
 			continue
 		}
+		if !runner.reportOwners(r) {
+			continue
+		}
 
 		stat.all++
 
@@ -376,38 +591,29 @@ func processReports(runner *LinterRunner, cfg *MainConfig, diff []*linter.Report
 		filtered = append(filtered, r)
 	}
 
-	if runner.flags.OutputJSON {
-		type reportList struct {
-			Reports []*linter.Report
-			Errors  []string
-		}
-		list := &reportList{
-			Reports: filtered,
-		}
-		d := json.NewEncoder(runner.outputFp)
-		if err := d.Encode(list); err != nil {
-			// Should never fail to marshal our own reports.
-			panic(fmt.Sprintf("report list marshaling failed: %v", err))
-		}
-	} else {
-		for _, report := range filtered {
-			format := ""
-
-			if runner.checkersFilter.IsCriticalReport(report) {
-				format += "<critical> "
-			}
-
-			if runner.config.Checkers.Autofixable(report.CheckName) {
-				format += "<autofixable> "
-			}
+	if runner.flags.AnnotateBlame {
+		annotateBlame(filtered, runner.config.MaxConcurrency)
+	}
 
-			format += "%s\n"
+	writeReports := outputFormats[runner.flags.OutputFormat]
+	if err := writeReports(runner.outputFp, runner, filtered); err != nil {
+		return stat, fmt.Errorf("write reports: %v", err)
+	}
 
-			fmt.Fprintf(runner.outputFp, format, FormatReport(report))
+	if !cfg.DisableAfterReportsLog {
+		if runner.flags.AnnotateBlame {
+			printReportsSummary(runner, filtered, "Author", func(r *linter.Report) []string {
+				return []string{r.Author}
+			})
+		}
+		if runner.flags.GroupBy == "owner" {
+			printReportsSummary(runner, filtered, "Owner", func(r *linter.Report) []string {
+				return r.Owners
+			})
 		}
 	}
 
-	return stat
+	return stat, nil
 }
 
 func InitStubs(l *linter.Linter) error {
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestCheckModeFlags(t *testing.T) {
+	tests := []struct {
+		flags ParsedFlags
+		err   string
+	}{
+		{flags: ParsedFlags{}},
+		{flags: ParsedFlags{GitRepo: ".git"}},
+		{flags: ParsedFlags{GitRepo: ".git", GitStaged: true}},
+		{flags: ParsedFlags{GitRepo: ".git", Baseline: "baseline.json"}},
+		{flags: ParsedFlags{DiffFile: "a.diff"}},
+		{flags: ParsedFlags{OutputBaseline: true}},
+		{flags: ParsedFlags{rewrite: true}},
+
+		{
+			flags: ParsedFlags{GitStaged: true},
+			err:   "--git-staged requires --git",
+		},
+		{
+			flags: ParsedFlags{GitRepo: ".git", DiffFile: "a.diff"},
+			err:   "--git and --diff-file can't be used together",
+		},
+		{
+			flags: ParsedFlags{rewrite: true, GitRepo: ".git"},
+			err:   "rewrite can't be used with --git or --diff-file",
+		},
+		{
+			flags: ParsedFlags{rewrite: true, DiffFile: "a.diff"},
+			err:   "rewrite can't be used with --git or --diff-file",
+		},
+		{
+			flags: ParsedFlags{GitRepo: ".git", baselineUpdate: true},
+			err:   "baseline update can't be used with --git",
+		},
+		{
+			flags: ParsedFlags{GitRepo: ".git", GitStaged: true, BaselineReportStale: true},
+			err:   "--baseline-report-stale can't be used with --git",
+		},
+		{
+			flags: ParsedFlags{DiffFile: "-", OutputBaseline: true},
+			err:   "--output-baseline can't be used with --diff-file",
+		},
+		{
+			flags: ParsedFlags{DiffFile: "-", baselineUpdate: true},
+			err:   "baseline update can't be used with --diff-file",
+		},
+		{
+			flags: ParsedFlags{DiffFile: "-", BaselineReportStale: true},
+			err:   "--baseline-report-stale can't be used with --diff-file",
+		},
+	}
+
+	for _, test := range tests {
+		err := checkModeFlags(&test.flags)
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%+v: unexpected error: %v", test.flags, err)
+		case test.err != "" && err == nil:
+			t.Errorf("%+v: expected error %q", test.flags, test.err)
+		case test.err != "" && err.Error() != test.err:
+			t.Errorf("%+v: error: have %q, want %q", test.flags, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/VKCOM/noverify/src/linter"
+)
+
+// reportsWriter writes the filtered reports in some output format.
+type reportsWriter func(w io.Writer, runner *LinterRunner, reports []*linter.Report) error
+
+// outputFormats maps the --output-format values to their writers.
+var outputFormats = map[string]reportsWriter{
+	"text":       writeTextReports,
+	"json":       writeJSONReports,
+	"checkstyle": writeCheckstyleReports,
+	"junit":      writeJUnitReports,
+	"gitlab":     writeGitLabReports,
+	"github":     writeGitHubReports,
+	"html":       writeHTMLReports,
+	"template":   writeTemplateReports,
+}
+
+// outputFormatNames returns the sorted list of the supported output formats.
+func outputFormatNames() []string {
+	names := make([]string, 0, len(outputFormats))
+	for name := range outputFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (l *LinterRunner) initOutputFormat() error {
+	// An empty format is the same as the default one, so the runners
+	// that fill the flags without the command line parsing work as usual.
+	if l.flags.OutputFormat == "" {
+		l.flags.OutputFormat = "text"
+	}
+
+	if l.flags.OutputJSON {
+		if l.flags.OutputFormat != "text" && l.flags.OutputFormat != "json" {
+			return fmt.Errorf("--output-json can't be used with --output-format=%s", l.flags.OutputFormat)
+		}
+		l.flags.OutputFormat = "json"
+	}
+
+	if l.flags.OutputTemplate != "" {
+		if l.flags.OutputFormat != "text" && l.flags.OutputFormat != "template" {
+			return fmt.Errorf("--output-template can't be used with --output-format=%s", l.flags.OutputFormat)
+		}
+		l.flags.OutputFormat = "template"
+	}
+	if l.flags.OutputFormat == "template" {
+		if l.flags.OutputTemplate == "" {
+			return fmt.Errorf("--output-format=template requires --output-template")
+		}
+		t, err := parseReportTemplate(l.flags)
+		if err != nil {
+			return fmt.Errorf("--output-template: %v", err)
+		}
+		l.outputTemplate = t
+	}
+
+	if _, ok := outputFormats[l.flags.OutputFormat]; !ok {
+		return fmt.Errorf("unsupported --output-format=%s, supported formats are %s",
+			l.flags.OutputFormat, strings.Join(outputFormatNames(), ", "))
+	}
+
+	return nil
+}
+
+func writeTextReports(w io.Writer, runner *LinterRunner, reports []*linter.Report) error {
+	for _, report := range reports {
+		format := ""
+
+		if runner.checkersFilter.IsCriticalReport(report) {
+			format += "<critical> "
+		}
+
+		if runner.config.Checkers.Autofixable(report.CheckName) {
+			format += "<autofixable> "
+		}
+
+		format += "%s\n"
+
+		if _, err := fmt.Fprintf(w, format, FormatReport(report)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSONReports(w io.Writer, runner *LinterRunner, reports []*linter.Report) error {
+	type reportList struct {
+		Reports []*linter.Report
+		Errors  []string
+	}
+	list := &reportList{
+		Reports: reports,
+	}
+	return json.NewEncoder(w).Encode(list)
+}
+
+// groupReportsByFile returns the reports grouped by file.
+// Files are sorted by name, reports inside the file are sorted by position.
+func groupReportsByFile(reports []*linter.Report) (filenames []string, byFile map[string][]*linter.Report) {
+	byFile = make(map[string][]*linter.Report)
+	for _, r := range reports {
+		if _, ok := byFile[r.Filename]; !ok {
+			filenames = append(filenames, r.Filename)
+		}
+		byFile[r.Filename] = append(byFile[r.Filename], r)
+	}
+
+	sort.Strings(filenames)
+	for _, list := range byFile {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Line != list[j].Line {
+				return list[i].Line < list[j].Line
+			}
+			return list[i].StartChar < list[j].StartChar
+		})
+	}
+
+	return filenames, byFile
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+)
+
+func TestInitWithoutOutputFlags(t *testing.T) {
+	lint := linter.NewLinter(linter.NewConfig("8.1"))
+	runner := NewLinterRunner(lint, linter.NewCheckersFilterWithEnabledAll())
+
+	err := runner.Init(nil, &ParsedFlags{
+		AllowAll:         true,
+		AllowChecks:      AllChecks,
+		PhpExtensionsArg: "php",
+	})
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if runner.flags.OutputFormat != "text" {
+		t.Errorf("output format: have %q, want text", runner.flags.OutputFormat)
+	}
+}
+
+func TestCheckstyleReports(t *testing.T) {
+	runner, reports := newOutputTestRunner()
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="/outside/c.php">
+    <error line="2" column="1" severity="warning" message="Variable $y is unused" source="noverify.unused"></error>
+  </file>
+  <file name="src/a.php">
+    <error line="3" column="1" severity="info" message="Maybe use &lt;b&gt; &amp; &#34;c&#34; instead" source="noverify.strictCmp"></error>
+    <error line="10" column="5" severity="error" message="Class \A\B not found" source="noverify.undefinedClass"></error>
+  </file>
+  <file name="src/b.php">
+    <error line="1" column="3" severity="warning" message="Variable $x is unused" source="noverify.unused"></error>
+  </file>
+</checkstyle>
+`
+	var buf bytes.Buffer
+	if err := writeCheckstyleReports(&buf, runner, reports); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("unexpected output (-want +have):\n%s", diff)
+	}
+}
+
+func TestJUnitReports(t *testing.T) {
+	runner, reports := newOutputTestRunner()
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="noverify" tests="3" failures="3" errors="1">
+    <testcase name="/outside/c.php" classname="/outside/c.php">
+      <failure message="Variable $y is unused" type="unused">WARNING unused: Variable $y is unused at /outside/c.php:2&#xA;$y = 1;&#xA;^^</failure>
+    </testcase>
+    <testcase name="src/a.php" classname="src/a.php">
+      <error message="Class \A\B not found" type="undefinedClass">ERROR   undefinedClass: Class \A\B not found at src/a.php:10&#xA;    new \A\B;&#xA;    ^^^^</error>
+      <failure message="Maybe use &lt;b&gt; &amp; &#34;c&#34; instead" type="strictCmp">MAYBE   strictCmp: Maybe use &lt;b&gt; &amp; &#34;c&#34; instead at src/a.php:3&#xA;$a == &#34;c&#34;;&#xA;</failure>
+    </testcase>
+    <testcase name="src/b.php" classname="src/b.php">
+      <failure message="Variable $x is unused" type="unused">WARNING unused: Variable $x is unused at src/b.php:1&#xA;  $x = 1;&#xA;  ^^</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	var buf bytes.Buffer
+	if err := writeJUnitReports(&buf, runner, reports); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("unexpected output (-want +have):\n%s", diff)
+	}
+}
+
+// newOutputTestRunner returns a runner for the output format tests
+// and the unsorted reports for the files inside and outside its project root.
+func newOutputTestRunner() (*LinterRunner, []*linter.Report) {
+	root := string(filepath.Separator) + "project"
+	checkersFilter := linter.NewCheckersFilter()
+	checkersFilter.Critical["undefinedClass"] = true
+
+	lint := linter.NewLinter(linter.NewConfig("8.1"))
+	runner := NewLinterRunner(lint, checkersFilter)
+	runner.flags = &ParsedFlags{}
+	runner.projectRoot = root
+	runner.outputFp = os.Stderr
+
+	reports := []*linter.Report{
+		{
+			CheckName: "unused",
+			Level:     lintapi.LevelWarning,
+			Context:   "  $x = 1;",
+			Message:   "Variable $x is unused",
+			Filename:  filepath.Join(root, "src", "b.php"),
+			Line:      1,
+			StartChar: 2,
+			EndChar:   4,
+			Hash:      1,
+		},
+		{
+			CheckName: "undefinedClass",
+			Level:     lintapi.LevelError,
+			Context:   "    new \\A\\B;",
+			Message:   "Class \\A\\B not found",
+			Filename:  filepath.Join(root, "src", "a.php"),
+			Line:      10,
+			StartChar: 4,
+			EndChar:   8,
+			Hash:      2,
+		},
+		{
+			CheckName: "strictCmp",
+			Level:     lintapi.LevelNotice,
+			Context:   `$a == "c";`,
+			Message:   `Maybe use <b> & "c" instead`,
+			Filename:  filepath.Join(root, "src", "a.php"),
+			Line:      3,
+			Hash:      3,
+		},
+		{
+			CheckName: "unused",
+			Level:     lintapi.LevelWarning,
+			Context:   "$y = 1;",
+			Message:   "Variable $y is unused",
+			Filename:  filepath.Join(string(filepath.Separator)+"outside", "c.php"),
+			Line:      2,
+			EndChar:   2,
+			Hash:      4,
+		},
+	}
+
+	return runner, reports
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk is full")
+}
+
+func TestProcessReportsWriteError(t *testing.T) {
+	runner, reports := newOutputTestRunner()
+	runner.flags.OutputFormat = "text"
+	runner.outputFp = failingWriter{}
+
+	_, err := processReports(runner, &MainConfig{DisableAfterReportsLog: true}, reports)
+	if err == nil || !strings.Contains(err.Error(), "disk is full") {
+		t.Errorf("have error %v, want the writer error", err)
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/linter"
+)
+
+// reportTemplate is a set of the user-defined output templates.
+// Header and footer are nil if they're not specified.
+type reportTemplate struct {
+	report *template.Template
+	header *template.Template
+	footer *template.Template
+}
+
+// templateReport is the data of the --output-template template.
+type templateReport struct {
+	*linter.Report
+
+	// Severity is a severity name, like WARNING.
+	Severity string
+	// Column is a 1-based StartChar.
+	Column int
+	// RelPath is a file path relative to the current directory.
+	RelPath     string
+	Critical    bool
+	Autofixable bool
+}
+
+// templateSummary is the data of the header and footer templates.
+type templateSummary struct {
+	Reports     []templateReport
+	Total       int
+	Critical    int
+	Minor       int
+	Autofixable int
+}
+
+func parseReportTemplate(flags *ParsedFlags) (*reportTemplate, error) {
+	var t reportTemplate
+	var err error
+
+	t.report, err = template.New("output-template").Parse(flags.OutputTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if flags.OutputTemplateHeader != "" {
+		t.header, err = template.New("output-template-header").Parse(flags.OutputTemplateHeader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if flags.OutputTemplateFooter != "" {
+		t.footer, err = template.New("output-template-footer").Parse(flags.OutputTemplateFooter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Execute the templates on empty data to report unknown fields
+	// before the linting starts.
+	report := templateReport{Report: &linter.Report{}}
+	if err := executeTemplateLine(io.Discard, t.report, report); err != nil {
+		return nil, err
+	}
+	summary := templateSummary{Reports: []templateReport{report}}
+	if err := executeTemplateLine(io.Discard, t.header, summary); err != nil {
+		return nil, err
+	}
+	if err := executeTemplateLine(io.Discard, t.footer, summary); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// writeTemplateReports executes the --output-template template for every report.
+// Every execution result is followed by a newline.
+func writeTemplateReports(w io.Writer, runner *LinterRunner, reports []*linter.Report) error {
+	summary := templateSummary{Reports: make([]templateReport, 0, len(reports))}
+	for _, r := range reports {
+		data := templateReport{
+			Report:      r,
+			Severity:    r.Severity(),
+			Column:      r.StartChar + 1,
+			RelPath:     baseline.RelativePath(runner.projectRoot, r.Filename),
+			Critical:    runner.checkersFilter.IsCriticalReport(r),
+			Autofixable: runner.config.Checkers.Autofixable(r.CheckName),
+		}
+		summary.Reports = append(summary.Reports, data)
+
+		summary.Total++
+		if data.Critical {
+			summary.Critical++
+		} else {
+			summary.Minor++
+		}
+		if data.Autofixable {
+			summary.Autofixable++
+		}
+	}
+
+	t := runner.outputTemplate
+	if err := executeTemplateLine(w, t.header, summary); err != nil {
+		return err
+	}
+	for _, data := range summary.Reports {
+		if err := executeTemplateLine(w, t.report, data); err != nil {
+			return err
+		}
+	}
+	return executeTemplateLine(w, t.footer, summary)
+}
+
+func executeTemplateLine(w io.Writer, t *template.Template, data interface{}) error {
+	if t == nil {
+		return nil
+	}
+	if err := t.Execute(w, data); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
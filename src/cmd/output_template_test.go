@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"text/template"
+)
+
+func TestExecuteTemplateLine(t *testing.T) {
+	var buf bytes.Buffer
+	if err := executeTemplateLine(&buf, nil, 1); err != nil || buf.Len() != 0 {
+		t.Errorf("nil template: have %q and error %v, want nothing", buf.String(), err)
+	}
+
+	tmpl := template.Must(template.New("test").Parse(`value={{.}}`))
+	buf.Reset()
+	if err := executeTemplateLine(&buf, tmpl, 10); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if buf.String() != "value=10\n" {
+		t.Errorf("have %q, want %q", buf.String(), "value=10\n")
+	}
+
+	failing := template.Must(template.New("test").Funcs(template.FuncMap{
+		"fail": func() (string, error) { return "", errors.New("failed") },
+	}).Parse(`{{fail}}`))
+	if err := executeTemplateLine(&buf, failing, nil); err == nil {
+		t.Errorf("expected the template error")
+	}
+}
+
+func TestTemplateReports(t *testing.T) {
+	runner, reports := newOutputTestRunner()
+	runner.flags.OutputTemplate = `{{.RelPath}}:{{.Line}}:{{.Column}} {{.Severity}} {{printf "%T" .Severity}} {{.Report.Severity}} {{.Critical}} {{.CheckName}}`
+	runner.flags.OutputTemplateHeader = `total={{.Total}} critical={{.Critical}} minor={{.Minor}} reports={{len .Reports}}`
+	runner.flags.OutputTemplateFooter = `{{range .Reports}}{{.Severity}};{{end}}`
+
+	tmpl, err := parseReportTemplate(runner.flags)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	runner.outputTemplate = tmpl
+
+	want := "total=4 critical=1 minor=3 reports=4\n" +
+		"src/b.php:1:3 WARNING string WARNING false unused\n" +
+		"src/a.php:10:5 ERROR string ERROR true undefinedClass\n" +
+		"src/a.php:3:1 MAYBE string MAYBE false strictCmp\n" +
+		"/outside/c.php:2:1 WARNING string WARNING false unused\n" +
+		"WARNING;ERROR;MAYBE;WARNING;\n"
+
+	var buf bytes.Buffer
+	if err := writeTemplateReports(&buf, runner, reports); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if buf.String() != want {
+		t.Errorf("unexpected output:\nhave:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestParseReportTemplateUnknownField(t *testing.T) {
+	tests := []ParsedFlags{
+		{OutputTemplate: `{{.Nope}}`},
+		{OutputTemplate: `{{.Line}}`, OutputTemplateHeader: `{{.Nope}}`},
+		{OutputTemplate: `{{.Line}}`, OutputTemplateFooter: `{{range .Reports}}{{.Nope}}{{end}}`},
+	}
+
+	for _, flags := range tests {
+		flags := flags
+		if _, err := parseReportTemplate(&flags); err == nil {
+			t.Errorf("%+v: expected an error", flags)
+		}
+	}
+
+	flags := &ParsedFlags{
+		OutputTemplate:       `{{.RelPath}}:{{.Line}} {{.Report.Severity}}`,
+		OutputTemplateFooter: `{{range .Reports}}{{.CheckName}}{{end}}`,
+	}
+	if _, err := parseReportTemplate(flags); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -1,14 +1,14 @@
-package dupcode
+package complexity
 
 import (
 	"github.com/VKCOM/noverify/src/ir"
 )
 
-// assertComplexity reports whether the given node list reaches the min complexity value.
+// AtLeast reports whether the given node list reaches the min complexity value.
 //
 // We don't calculate the precise complexity to avoid full IR traversal for big functions.
 // When we reached the min complexity goal, the traversal terminates.
-func assertComplexity(list []ir.Node, min uint) bool {
+func AtLeast(list []ir.Node, min uint) bool {
 	// Most nodes have the "cost" of at least 1, so
 	// if we have more than min nodes, we'll probably will
 	// reach the complexity requirement.
@@ -16,7 +16,7 @@ func assertComplexity(list []ir.Node, min uint) bool {
 		return true
 	}
 
-	c := complexityWalker{target: min}
+	c := costWalker{target: min}
 	for _, n := range list {
 		n.Walk(&c)
 		if c.goalReached() {
@@ -26,18 +26,18 @@ func assertComplexity(list []ir.Node, min uint) bool {
 	return c.goalReached()
 }
 
-type complexityWalker struct {
+type costWalker struct {
 	current uint
 	target  uint
 }
 
-func (c *complexityWalker) goalReached() bool {
+func (c *costWalker) goalReached() bool {
 	return c.current >= c.target
 }
 
-func (c *complexityWalker) LeaveNode(n ir.Node) {}
+func (c *costWalker) LeaveNode(n ir.Node) {}
 
-func (c *complexityWalker) EnterNode(n ir.Node) bool {
+func (c *costWalker) EnterNode(n ir.Node) bool {
 	if c.goalReached() {
 		return false
 	}
@@ -0,0 +1,200 @@
+// Package complexity computes the code complexity values
+// that are used by the php-guru commands.
+package complexity
+
+import (
+	"github.com/VKCOM/noverify/src/ir"
+)
+
+// Walker computes the complexity metrics of a single function body.
+//
+// Cyclomatic complexity is a number of decision points plus one.
+//
+// Cognitive complexity follows the rules described in the
+// "Cognitive Complexity" whitepaper by G. Ann Campbell:
+// control flow structures get a +1 increment plus the current nesting level,
+// else/elseif branches and boolean operator sequences get a flat +1.
+// Closures and anonymous classes increase the nesting level.
+//
+// Nested function and class declarations are not visited.
+type Walker struct {
+	cyclomatic int
+	cognitive  int
+	nesting    int
+	maxNesting int
+
+	// elseIfs is a set of if statements that are a part of `else if` construction.
+	// They don't increase the nesting level.
+	elseIfs map[ir.Node]struct{}
+
+	// boolSeqTail is a set of boolean expressions that
+	// continue the operator sequence started by their parent.
+	boolSeqTail map[ir.Node]struct{}
+}
+
+// NewWalker returns a walker with zero metrics.
+func NewWalker() *Walker {
+	return &Walker{
+		elseIfs:     make(map[ir.Node]struct{}),
+		boolSeqTail: make(map[ir.Node]struct{}),
+	}
+}
+
+// Cyclomatic returns the cyclomatic complexity of the visited nodes.
+func (w *Walker) Cyclomatic() int { return w.cyclomatic + 1 }
+
+// Cognitive returns the cognitive complexity of the visited nodes.
+func (w *Walker) Cognitive() int { return w.cognitive }
+
+// MaxNesting returns the max nesting level of the visited nodes.
+func (w *Walker) MaxNesting() int { return w.maxNesting }
+
+func (w *Walker) EnterNode(n ir.Node) bool {
+	switch n := n.(type) {
+	case *ir.FunctionStmt, *ir.ClassStmt, *ir.InterfaceStmt, *ir.TraitStmt:
+		// Nested declarations are reported as separate symbols.
+		return false
+
+	case *ir.IfStmt:
+		w.cyclomatic++
+		if _, ok := w.elseIfs[n]; ok {
+			// `else if` is counted as a single flat increment.
+			w.cognitive++
+			return true
+		}
+		w.addStructure()
+
+	case *ir.ElseIfStmt:
+		w.cyclomatic++
+		w.cognitive++
+
+	case *ir.ElseStmt:
+		if n.Stmt != nil {
+			if ifStmt, ok := n.Stmt.(*ir.IfStmt); ok {
+				w.elseIfs[ifStmt] = struct{}{}
+				return true
+			}
+		}
+		w.cognitive++
+
+	case *ir.ForStmt, *ir.ForeachStmt, *ir.WhileStmt, *ir.DoStmt, *ir.CatchStmt:
+		w.cyclomatic++
+		w.addStructure()
+
+	case *ir.SwitchStmt, *ir.MatchExpr:
+		w.addStructure()
+
+	case *ir.CaseStmt:
+		w.cyclomatic++
+
+	case *ir.MatchArm:
+		if !n.IsDefault {
+			w.cyclomatic += len(n.Exprs)
+		}
+
+	case *ir.TernaryExpr:
+		w.cyclomatic++
+		w.addStructure()
+
+	case *ir.CoalesceExpr:
+		w.cyclomatic++
+
+	case *ir.ClosureExpr, *ir.ArrowFunctionExpr, *ir.AnonClassExpr:
+		w.enterNesting()
+
+	case *ir.BooleanAndExpr, *ir.BooleanOrExpr, *ir.LogicalAndExpr, *ir.LogicalOrExpr:
+		w.cyclomatic++
+		w.handleBoolOp(n)
+	case *ir.LogicalXorExpr:
+		w.handleBoolOp(n)
+
+	case *ir.GotoStmt:
+		w.cognitive++
+	case *ir.BreakStmt:
+		if n.Expr != nil {
+			w.cognitive++
+		}
+	case *ir.ContinueStmt:
+		if n.Expr != nil {
+			w.cognitive++
+		}
+	}
+
+	return true
+}
+
+func (w *Walker) LeaveNode(n ir.Node) {
+	switch n := n.(type) {
+	case *ir.IfStmt:
+		if _, ok := w.elseIfs[n]; !ok {
+			w.leaveNesting()
+		}
+	case *ir.ForStmt, *ir.ForeachStmt, *ir.WhileStmt, *ir.DoStmt, *ir.CatchStmt,
+		*ir.SwitchStmt, *ir.MatchExpr, *ir.TernaryExpr,
+		*ir.ClosureExpr, *ir.ArrowFunctionExpr, *ir.AnonClassExpr:
+		w.leaveNesting()
+	}
+}
+
+func (w *Walker) addStructure() {
+	w.cognitive += 1 + w.nesting
+	w.enterNesting()
+}
+
+func (w *Walker) enterNesting() {
+	w.nesting++
+	if w.nesting > w.maxNesting {
+		w.maxNesting = w.nesting
+	}
+}
+
+func (w *Walker) leaveNesting() {
+	w.nesting--
+}
+
+// handleBoolOp adds a cognitive complexity increment for every
+// sequence of the same boolean operators, so `$a && $b && $c` costs 1
+// while `$a && $b || $c` costs 2.
+func (w *Walker) handleBoolOp(n ir.Node) {
+	if _, ok := w.boolSeqTail[n]; !ok {
+		w.cognitive++
+	}
+	if left := boolOpLeft(n); left != nil && sameBoolOp(n, left) {
+		w.boolSeqTail[left] = struct{}{}
+	}
+}
+
+func boolOpLeft(n ir.Node) ir.Node {
+	switch n := n.(type) {
+	case *ir.BooleanAndExpr:
+		return n.Left
+	case *ir.BooleanOrExpr:
+		return n.Left
+	case *ir.LogicalAndExpr:
+		return n.Left
+	case *ir.LogicalOrExpr:
+		return n.Left
+	case *ir.LogicalXorExpr:
+		return n.Left
+	}
+	return nil
+}
+
+func sameBoolOp(x, y ir.Node) bool {
+	switch x.(type) {
+	case *ir.BooleanAndExpr, *ir.LogicalAndExpr:
+		switch y.(type) {
+		case *ir.BooleanAndExpr, *ir.LogicalAndExpr:
+			return true
+		}
+	case *ir.BooleanOrExpr, *ir.LogicalOrExpr:
+		switch y.(type) {
+		case *ir.BooleanOrExpr, *ir.LogicalOrExpr:
+			return true
+		}
+	case *ir.LogicalXorExpr:
+		_, ok := y.(*ir.LogicalXorExpr)
+		return ok
+	}
+	return false
+}
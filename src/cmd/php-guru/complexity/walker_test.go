@@ -0,0 +1,100 @@
+package complexity
+
+import (
+	"testing"
+
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irconv"
+	"github.com/VKCOM/noverify/src/php/parseutil"
+)
+
+func TestWalker(t *testing.T) {
+	tests := []struct {
+		code       string
+		cyclomatic int
+		cognitive  int
+		nesting    int
+	}{
+		{`return 1;`, 1, 0, 0},
+
+		{`if ($a) { f(); }`, 2, 1, 1},
+		{`if ($a) { f(); } else { g(); }`, 2, 2, 1},
+		{`if ($a) { f(); } elseif ($b) { g(); } else { h(); }`, 3, 3, 1},
+		{`if ($a) { f(); } else if ($b) { g(); }`, 3, 2, 1},
+
+		// Nested structures get the nesting increment.
+		{`if ($a) { foreach ($xs as $x) { if ($x) { f(); } } }`, 4, 6, 3},
+		{`while ($a) { for (;;) { f(); } }`, 3, 3, 2},
+
+		// Boolean operator sequences.
+		{`return $a && $b && $c;`, 3, 1, 0},
+		{`return $a && $b || $c;`, 3, 2, 0},
+		{`return $a xor $b;`, 1, 1, 0},
+
+		{`switch ($a) { case 1: f(); break; case 2: g(); break; default: h(); }`, 3, 1, 1},
+		{`return $a ? 1 : 2;`, 2, 1, 1},
+		{`return $a ?? 1;`, 2, 0, 0},
+		{`try { f(); } catch (E $e) { g(); }`, 2, 1, 1},
+
+		// Jumps to labels.
+		{`while ($a) { while ($b) { break 2; } }`, 3, 4, 2},
+		{`goto end; end: f();`, 1, 1, 0},
+
+		// Closures increase the nesting level.
+		{`$f = function() { if ($a) { f(); } };`, 2, 2, 2},
+		{`$f = fn() => $a ? 1 : 2;`, 2, 2, 2},
+
+		// Nested declarations are not visited.
+		{`function g() { if ($a) { f(); } }`, 1, 0, 0},
+		{`class C { function m() { if ($a) { f(); } } }`, 1, 0, 0},
+	}
+
+	for _, test := range tests {
+		w := NewWalker()
+		for _, stmt := range parseStmts(t, test.code) {
+			stmt.Walk(w)
+		}
+
+		if w.Cyclomatic() != test.cyclomatic {
+			t.Errorf("%s: cyclomatic: have %d, want %d", test.code, w.Cyclomatic(), test.cyclomatic)
+		}
+		if w.Cognitive() != test.cognitive {
+			t.Errorf("%s: cognitive: have %d, want %d", test.code, w.Cognitive(), test.cognitive)
+		}
+		if w.MaxNesting() != test.nesting {
+			t.Errorf("%s: nesting: have %d, want %d", test.code, w.MaxNesting(), test.nesting)
+		}
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		code string
+		min  uint
+		want bool
+	}{
+		{`f();`, 2, true},
+		{`f();`, 10, false},
+		{`if ($a) { f(); }`, 5, true},
+		{`foreach ($xs as $x) { f($x); }`, 8, true},
+		{`foreach ($xs as $x) { f($x); }`, 20, false},
+		{`f(); g();`, 2, true},
+	}
+
+	for _, test := range tests {
+		have := AtLeast(parseStmts(t, test.code), test.min)
+		if have != test.want {
+			t.Errorf("%s: AtLeast(%d): have %v, want %v", test.code, test.min, have, test.want)
+		}
+	}
+}
+
+func parseStmts(t *testing.T, code string) []ir.Node {
+	t.Helper()
+
+	root, err := parseutil.ParseFile([]byte("<?php " + code))
+	if err != nil {
+		t.Fatalf("parse %s: %v", code, err)
+	}
+	return irconv.ConvertNode(root).(*ir.Root).Stmts
+}
@@ -1,6 +1,7 @@
 package dupcode
 
 import (
+	"github.com/VKCOM/noverify/src/cmd/php-guru/complexity"
 	"github.com/VKCOM/noverify/src/ir"
 	"github.com/VKCOM/noverify/src/ir/normalize"
 	"github.com/VKCOM/noverify/src/meta"
@@ -54,7 +55,7 @@ func (indexer *fileIndexer) walkMethod(className string, n *ir.ClassMethodStmt)
 	if !indexer.args.checkPrivate && hasModifier(n.Modifiers, "private") {
 		return
 	}
-	if !assertComplexity(body.Stmts, indexer.args.minComplexity) {
+	if !complexity.AtLeast(body.Stmts, indexer.args.minComplexity) {
 		return
 	}
 
@@ -62,7 +63,7 @@ func (indexer *fileIndexer) walkMethod(className string, n *ir.ClassMethodStmt)
 }
 
 func (indexer *fileIndexer) walkFunc(n *ir.FunctionStmt) {
-	if !assertComplexity(n.Stmts, indexer.args.minComplexity) {
+	if !complexity.AtLeast(n.Stmts, indexer.args.minComplexity) {
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/VKCOM/noverify/src/cmd/php-guru/dupcode"
 	"github.com/VKCOM/noverify/src/cmd/php-guru/guru"
+	"github.com/VKCOM/noverify/src/cmd/php-guru/metrics"
 )
 
 var commands []*subCommand
@@ -40,6 +41,30 @@ func guruCommands() []*subCommand {
 				},
 			},
 		},
+
+		{
+			name:    "metrics",
+			main:    metrics.Main,
+			summary: "print complexity metrics of functions and methods",
+			examples: []subCommandExample{
+				{
+					comment: "show metrics sub-command help",
+					line:    "-help",
+				},
+				{
+					comment: "print JSON report with per-symbol, per-file and per-namespace metrics",
+					line:    "path/to/project",
+				},
+				{
+					comment: "print per-namespace aggregates in CSV format",
+					line:    "-format=csv -csv-table=namespaces path/to/project",
+				},
+				{
+					comment: "fail if some function is too complex",
+					line:    "-max-cognitive=30 -output=metrics.json path/to/project",
+				},
+			},
+		},
 	}
 
 	sort.Slice(commands, func(i, j int) bool {
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"runtime"
+	"sync"
+
+	"github.com/VKCOM/noverify/src/cmd/php-guru/guru"
+	"github.com/VKCOM/noverify/src/ir/irconv"
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/php/parseutil"
+	"github.com/VKCOM/noverify/src/workspace"
+)
+
+func Main(ctx *guru.Context) (int, error) {
+	var args arguments
+	flag.StringVar(&args.format, "format", "json",
+		`output format: "json" or "csv"`)
+	flag.StringVar(&args.csvTable, "csv-table", "symbols",
+		`table to print in CSV format: "symbols", "files" or "namespaces"`)
+	flag.StringVar(&args.output, "output", "",
+		"write the report to the specified file instead of stdout")
+	flag.BoolVar(&args.checkAutogen, "autogen", false,
+		"whether auto-generated files should be analyzed")
+	flag.StringVar(&args.exclude, "exclude", "",
+		"regexp that excludes files from the analysis")
+	flag.IntVar(&args.maxCyclomatic, "max-cyclomatic", 0,
+		"if positive, exit with non-zero status when some symbol cyclomatic complexity exceeds this value")
+	flag.IntVar(&args.maxCognitive, "max-cognitive", 0,
+		"if positive, exit with non-zero status when some symbol cognitive complexity exceeds this value")
+
+	flag.Parse()
+
+	if args.format != "json" && args.format != "csv" {
+		return 1, fmt.Errorf("invalid -format %q", args.format)
+	}
+	var exclude *regexp.Regexp
+	if args.exclude != "" {
+		var err error
+		exclude, err = regexp.Compile(args.exclude)
+		if err != nil {
+			return 1, fmt.Errorf("parse -exclude: %v", err)
+		}
+	}
+
+	targets := flag.Args()
+
+	filter := workspace.NewFilenameFilter(exclude)
+	readFileNamesFunc := workspace.ReadFilenames(targets, filter, []string{"php", "inc", "php5", "phtml"})
+	filenamesCh := make(chan workspace.FileInfo, 512)
+	go func() {
+		readFileNamesFunc(filenamesCh)
+		close(filenamesCh)
+	}()
+
+	nworkers := runtime.NumCPU()
+	results := make([][]*symbolInfo, nworkers)
+	var wg sync.WaitGroup
+	wg.Add(nworkers)
+	for i := 0; i < nworkers; i++ {
+		go func(workerID int) {
+			irConverter := irconv.NewConverter(nil)
+			var workerResult []*symbolInfo
+			for f := range filenamesCh {
+				data, err := os.ReadFile(f.Name)
+				if err != nil {
+					log.Printf("read %s file: %v", f.Name, err)
+					continue
+				}
+				if !args.checkAutogen && workspace.FileIsAutoGenerated(data) {
+					continue
+				}
+				root, err := parseutil.ParseFile(data)
+				if err != nil {
+					log.Printf("parse %s file: %v", f.Name, err)
+					continue
+				}
+				rootIR := irConverter.ConvertRoot(root)
+
+				walker := &fileWalker{
+					st:       &meta.ClassParseState{CurrentFile: f.Name},
+					filename: f.Name,
+				}
+				rootIR.Walk(walker)
+				workerResult = append(workerResult, walker.symbols...)
+			}
+			results[workerID] = workerResult
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+
+	allSymbols := []*symbolInfo{}
+	for _, workerResult := range results {
+		allSymbols = append(allSymbols, workerResult...)
+	}
+	r := newReport(allSymbols)
+
+	var w io.Writer = os.Stdout
+	if args.output != "" {
+		f, err := os.Create(args.output)
+		if err != nil {
+			return 1, fmt.Errorf("create output file: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var err error
+	switch args.format {
+	case "json":
+		err = printJSON(w, r)
+	case "csv":
+		err = printCSV(w, r, args.csvTable)
+	}
+	if err != nil {
+		return 1, err
+	}
+
+	if !checkThresholds(r, &args) {
+		return 2, nil
+	}
+	return 0, nil
+}
+
+// checkThresholds reports whether all symbols fit into the configured limits.
+// Every symbol that exceeds some limit is logged.
+func checkThresholds(r *report, args *arguments) bool {
+	ok := true
+	for _, sym := range r.Symbols {
+		if args.maxCyclomatic > 0 && sym.Cyclomatic > args.maxCyclomatic {
+			log.Printf("%s:%d: %s: cyclomatic complexity %d exceeds %d",
+				sym.File, sym.Line, sym.Name, sym.Cyclomatic, args.maxCyclomatic)
+			ok = false
+		}
+		if args.maxCognitive > 0 && sym.Cognitive > args.maxCognitive {
+			log.Printf("%s:%d: %s: cognitive complexity %d exceeds %d",
+				sym.File, sym.Line, sym.Name, sym.Cognitive, args.maxCognitive)
+			ok = false
+		}
+	}
+	return ok
+}
+
+type arguments struct {
+	format        string
+	csvTable      string
+	output        string
+	checkAutogen  bool
+	exclude       string
+	maxCyclomatic int
+	maxCognitive  int
+}
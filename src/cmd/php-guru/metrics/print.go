@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+func printJSON(w io.Writer, r *report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func printCSV(w io.Writer, r *report, table string) error {
+	var rows [][]string
+
+	switch table {
+	case "symbols":
+		rows = append(rows, []string{
+			"name", "kind", "file", "line", "namespace",
+			"loc", "cyclomatic", "cognitive", "nesting", "params", "fan_in", "fan_out",
+		})
+		for _, sym := range r.Symbols {
+			rows = append(rows, []string{
+				sym.Name, sym.Kind, sym.File, strconv.Itoa(sym.Line), sym.Namespace,
+				strconv.Itoa(sym.LOC),
+				strconv.Itoa(sym.Cyclomatic),
+				strconv.Itoa(sym.Cognitive),
+				strconv.Itoa(sym.Nesting),
+				strconv.Itoa(sym.Params),
+				strconv.Itoa(sym.FanIn),
+				strconv.Itoa(sym.FanOut),
+			})
+		}
+	case "files":
+		rows = aggregateRows("file", r.Files)
+	case "namespaces":
+		rows = aggregateRows("namespace", r.Namespaces)
+	default:
+		return fmt.Errorf("unknown CSV table %q", table)
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.WriteAll(rows); err != nil {
+		return err
+	}
+	return out.Error()
+}
+
+func aggregateRows(groupName string, list []*aggregateInfo) [][]string {
+	rows := [][]string{{
+		groupName, "symbols", "loc",
+		"cyclomatic_sum", "cyclomatic_max", "cyclomatic_avg",
+		"cognitive_sum", "cognitive_max", "cognitive_avg",
+		"nesting_max",
+	}}
+	for _, agg := range list {
+		rows = append(rows, []string{
+			agg.Name,
+			strconv.Itoa(agg.Symbols),
+			strconv.Itoa(agg.LOC),
+			strconv.Itoa(agg.CyclomaticSum),
+			strconv.Itoa(agg.CyclomaticMax),
+			strconv.FormatFloat(agg.CyclomaticAvg, 'f', 2, 64),
+			strconv.Itoa(agg.CognitiveSum),
+			strconv.Itoa(agg.CognitiveMax),
+			strconv.FormatFloat(agg.CognitiveAvg, 'f', 2, 64),
+			strconv.Itoa(agg.NestingMax),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+)
+
+// symbolInfo holds the metrics of a single function or method.
+type symbolInfo struct {
+	// Name is a fully qualified name, like `\NS\f` or `\NS\C::m`.
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Namespace  string `json:"namespace"`
+	LOC        int    `json:"loc"`
+	Cyclomatic int    `json:"cyclomatic"`
+	Cognitive  int    `json:"cognitive"`
+	Nesting    int    `json:"nesting"`
+	Params     int    `json:"params"`
+	FanIn      int    `json:"fan_in"`
+	FanOut     int    `json:"fan_out"`
+
+	calls map[string][]string
+}
+
+// aggregateInfo holds the summary metrics of a group of symbols,
+// like all symbols declared inside the same file or namespace.
+type aggregateInfo struct {
+	Name          string  `json:"name"`
+	Symbols       int     `json:"symbols"`
+	LOC           int     `json:"loc"`
+	CyclomaticSum int     `json:"cyclomatic_sum"`
+	CyclomaticMax int     `json:"cyclomatic_max"`
+	CyclomaticAvg float64 `json:"cyclomatic_avg"`
+	CognitiveSum  int     `json:"cognitive_sum"`
+	CognitiveMax  int     `json:"cognitive_max"`
+	CognitiveAvg  float64 `json:"cognitive_avg"`
+	NestingMax    int     `json:"nesting_max"`
+}
+
+func (agg *aggregateInfo) add(sym *symbolInfo) {
+	agg.Symbols++
+	agg.LOC += sym.LOC
+	agg.CyclomaticSum += sym.Cyclomatic
+	agg.CognitiveSum += sym.Cognitive
+	if sym.Cyclomatic > agg.CyclomaticMax {
+		agg.CyclomaticMax = sym.Cyclomatic
+	}
+	if sym.Cognitive > agg.CognitiveMax {
+		agg.CognitiveMax = sym.Cognitive
+	}
+	if sym.Nesting > agg.NestingMax {
+		agg.NestingMax = sym.Nesting
+	}
+	agg.CyclomaticAvg = float64(agg.CyclomaticSum) / float64(agg.Symbols)
+	agg.CognitiveAvg = float64(agg.CognitiveSum) / float64(agg.Symbols)
+}
+
+type report struct {
+	Symbols    []*symbolInfo    `json:"symbols"`
+	Files      []*aggregateInfo `json:"files"`
+	Namespaces []*aggregateInfo `json:"namespaces"`
+}
+
+// newReport computes the fan-in/fan-out values and
+// the per-file and per-namespace aggregates.
+func newReport(symbols []*symbolInfo) *report {
+	sort.Slice(symbols, func(i, j int) bool {
+		if symbols[i].File != symbols[j].File {
+			return symbols[i].File < symbols[j].File
+		}
+		return symbols[i].Line < symbols[j].Line
+	})
+
+	declared := make(map[string]*symbolInfo, len(symbols))
+	for _, sym := range symbols {
+		declared[symbolKey(sym.Name)] = sym
+	}
+
+	for _, sym := range symbols {
+		sym.FanOut = len(sym.calls)
+		callees := make(map[*symbolInfo]struct{})
+		for _, candidates := range sym.calls {
+			for _, name := range candidates {
+				callee, ok := declared[symbolKey(name)]
+				if ok {
+					callees[callee] = struct{}{}
+					break
+				}
+			}
+		}
+		for callee := range callees {
+			if callee != sym {
+				callee.FanIn++
+			}
+		}
+	}
+
+	return &report{
+		Symbols:    symbols,
+		Files:      aggregate(symbols, func(sym *symbolInfo) string { return sym.File }),
+		Namespaces: aggregate(symbols, func(sym *symbolInfo) string { return sym.Namespace }),
+	}
+}
+
+// symbolKey returns a call graph key for the fully qualified symbol name,
+// like `\NS\f` or `\NS\C::m`, so the same-named functions and methods
+// from different namespaces and classes don't clash.
+// PHP function, class and method names are case-insensitive.
+func symbolKey(fqn string) string {
+	return strings.ToLower(fqn)
+}
+
+func aggregate(symbols []*symbolInfo, groupKey func(*symbolInfo) string) []*aggregateInfo {
+	groups := make(map[string]*aggregateInfo)
+	for _, sym := range symbols {
+		key := groupKey(sym)
+		agg, ok := groups[key]
+		if !ok {
+			agg = &aggregateInfo{Name: key}
+			groups[key] = agg
+		}
+		agg.add(sym)
+	}
+
+	list := make([]*aggregateInfo, 0, len(groups))
+	for _, agg := range groups {
+		list = append(list, agg)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/VKCOM/noverify/src/ir/irconv"
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/php/parseutil"
+)
+
+func TestReportFanIn(t *testing.T) {
+	r := newReport(append(
+		walkFile(t, "a.php", `<?php
+namespace A;
+class X { public function run() { return 1; } }
+function helper() { return 1; }
+function callA() { return helper(); }
+`),
+		walkFile(t, "b.php", `<?php
+namespace B;
+class X { public function run() { return 1; } }
+function helper() { return 1; }
+class Y {
+  public function go() { return X::run() + X::run() + \A\X::run(); }
+  public function run() { return $this->go() + strlen(''); }
+}
+`)...,
+	))
+
+	type fan struct{ in, out int }
+	want := map[string]fan{
+		`\A\X::run`: {in: 1, out: 0},
+		`\A\helper`: {in: 1, out: 0},
+		`\A\callA`:  {in: 0, out: 1},
+		`\B\X::run`: {in: 1, out: 0},
+		`\B\helper`: {in: 0, out: 0},
+		`\B\Y::go`:  {in: 1, out: 2},
+		`\B\Y::run`: {in: 0, out: 2},
+	}
+
+	if len(r.Symbols) != len(want) {
+		t.Fatalf("symbols: have %d, want %d", len(r.Symbols), len(want))
+	}
+	for _, sym := range r.Symbols {
+		w, ok := want[sym.Name]
+		if !ok {
+			t.Errorf("unexpected symbol %s", sym.Name)
+			continue
+		}
+		if sym.FanIn != w.in || sym.FanOut != w.out {
+			t.Errorf("%s: fan-in/fan-out: have %d/%d, want %d/%d", sym.Name, sym.FanIn, sym.FanOut, w.in, w.out)
+		}
+	}
+}
+
+func TestReportAggregates(t *testing.T) {
+	r := newReport(walkFile(t, "a.php", `<?php
+function f($a) { if ($a) { return 1; } return 2; }
+function g($a, $b) { return $a && $b; }
+`))
+
+	if len(r.Files) != 1 || len(r.Namespaces) != 1 {
+		t.Fatalf("aggregates: have %d files and %d namespaces, want 1 and 1", len(r.Files), len(r.Namespaces))
+	}
+	agg := r.Namespaces[0]
+	if agg.Name != `\` {
+		t.Errorf("namespace: have %s, want \\", agg.Name)
+	}
+	if agg.Symbols != 2 || agg.CyclomaticSum != 4 || agg.CyclomaticMax != 2 || agg.CognitiveSum != 2 || agg.NestingMax != 1 {
+		t.Errorf("unexpected aggregate: %+v", agg)
+	}
+	if agg.CyclomaticAvg != 2 {
+		t.Errorf("cyclomatic avg: have %v, want 2", agg.CyclomaticAvg)
+	}
+}
+
+func walkFile(t *testing.T, filename, code string) []*symbolInfo {
+	t.Helper()
+
+	root, err := parseutil.ParseFile([]byte(code))
+	if err != nil {
+		t.Fatalf("parse %s: %v", filename, err)
+	}
+	walker := &fileWalker{
+		st:       &meta.ClassParseState{CurrentFile: filename},
+		filename: filename,
+	}
+	irconv.NewConverter(nil).ConvertRoot(root).Walk(walker)
+	return walker.symbols
+}
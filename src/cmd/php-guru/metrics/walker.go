@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/VKCOM/noverify/src/cmd/php-guru/complexity"
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/solver"
+	"github.com/VKCOM/noverify/src/state"
+)
+
+// fileWalker collects metrics for every function and method
+// declared inside a single file.
+type fileWalker struct {
+	st       *meta.ClassParseState
+	filename string
+	symbols  []*symbolInfo
+}
+
+func (w *fileWalker) EnterNode(n ir.Node) bool {
+	state.EnterNode(w.st, n)
+
+	switch n := n.(type) {
+	case *ir.AnonClassExpr:
+		// Anonymous classes are accounted as a part
+		// of the enclosing function, see funcWalker.
+		return false
+
+	case *ir.FunctionStmt:
+		w.addSymbol(n, "function", w.st.Namespace+`\`+n.FunctionName.Value, n.Params, n.Stmts)
+
+	case *ir.ClassMethodStmt:
+		body, ok := n.Stmt.(*ir.StmtList)
+		if !ok {
+			return false // Abstract or interface method
+		}
+		w.addSymbol(n, "method", w.st.CurrentClass+"::"+n.MethodName.Value, n.Params, body.Stmts)
+	}
+
+	return true
+}
+
+func (w *fileWalker) LeaveNode(n ir.Node) {
+	state.LeaveNode(w.st, n)
+}
+
+func (w *fileWalker) addSymbol(n ir.Node, kind, name string, params, stmts []ir.Node) {
+	fw := funcWalker{
+		st:         w.st,
+		complexity: complexity.NewWalker(),
+		calls:      make(map[string][]string),
+	}
+	for _, p := range params {
+		p.Walk(&fw)
+	}
+	for _, stmt := range stmts {
+		stmt.Walk(&fw)
+	}
+
+	namespace := w.st.Namespace
+	if namespace == "" {
+		namespace = `\`
+	}
+
+	pos := ir.GetPosition(n)
+	w.symbols = append(w.symbols, &symbolInfo{
+		Name:       name,
+		Kind:       kind,
+		File:       w.filename,
+		Line:       pos.StartLine,
+		Namespace:  namespace,
+		LOC:        pos.EndLine - pos.StartLine + 1,
+		Cyclomatic: fw.complexity.Cyclomatic(),
+		Cognitive:  fw.complexity.Cognitive(),
+		Nesting:    fw.complexity.MaxNesting(),
+		Params:     len(params),
+		calls:      fw.calls,
+	})
+}
+
+// funcWalker computes the complexity metrics and
+// collects the calls of a single function body.
+type funcWalker struct {
+	st *meta.ClassParseState
+
+	complexity *complexity.Walker
+
+	// calls maps a callee key to the list of candidate symbol names.
+	// The first candidate that is declared somewhere wins.
+	calls map[string][]string
+}
+
+func (w *funcWalker) EnterNode(n ir.Node) bool {
+	if !w.complexity.EnterNode(n) {
+		return false
+	}
+
+	switch n := n.(type) {
+	case *ir.FunctionCallExpr:
+		w.addFunctionCall(n.Function)
+	case *ir.StaticCallExpr:
+		w.addStaticCall(n.Class, n.Call)
+	case *ir.MethodCallExpr:
+		w.addMethodCall(n.Variable, n.Method)
+	case *ir.NullsafeMethodCallExpr:
+		w.addMethodCall(n.Variable, n.Method)
+	}
+
+	return true
+}
+
+func (w *funcWalker) LeaveNode(n ir.Node) {
+	w.complexity.LeaveNode(n)
+}
+
+// addFunctionCall records a function call.
+//
+// Unqualified function names are resolved at runtime: the namespaced
+// function is tried first, then the global one. Since we don't run
+// the indexing, both candidates are recorded and the choice is made
+// after all files are processed.
+func (w *funcWalker) addFunctionCall(fn ir.Node) {
+	nm, ok := fn.(*ir.Name)
+	if !ok {
+		w.addCall("dynamic function call", nil)
+		return
+	}
+	if nm.IsFullyQualified() {
+		w.addCall(nm.Value, []string{nm.Value})
+		return
+	}
+	if alias, ok := w.st.FunctionUses[nm.FirstPart()]; ok {
+		name := alias
+		if nm.NumParts() != 1 {
+			name = alias + `\` + nm.RestParts()
+		}
+		w.addCall(name, []string{name})
+		return
+	}
+
+	globalName := `\` + nm.Value
+	if w.st.Namespace == "" {
+		w.addCall(globalName, []string{globalName})
+		return
+	}
+	localName := w.st.Namespace + `\` + nm.Value
+	w.addCall(localName, []string{localName, globalName})
+}
+
+func (w *funcWalker) addStaticCall(class, call ir.Node) {
+	id, ok := call.(*ir.Identifier)
+	if !ok {
+		w.addCall("dynamic static call", nil)
+		return
+	}
+	className, ok := solver.GetClassName(w.st, class)
+	if !ok {
+		w.addCall("::"+id.Value, nil)
+		return
+	}
+	name := className + "::" + id.Value
+	w.addCall(name, []string{name})
+}
+
+func (w *funcWalker) addMethodCall(object, method ir.Node) {
+	id, ok := method.(*ir.Identifier)
+	if !ok {
+		w.addCall("dynamic method call", nil)
+		return
+	}
+	if v, ok := object.(*ir.SimpleVar); ok && v.Name == "this" && w.st.CurrentClass != "" {
+		name := w.st.CurrentClass + "::" + id.Value
+		w.addCall(name, []string{name})
+		return
+	}
+	// We can't resolve the object type without type inference,
+	// so such calls only contribute to the fan-out.
+	w.addCall("->"+id.Value, nil)
+}
+
+func (w *funcWalker) addCall(key string, candidates []string) {
+	key = strings.ToLower(key)
+	if _, ok := w.calls[key]; ok {
+		return
+	}
+	w.calls[key] = candidates
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VKCOM/noverify/src/baseline"
+	"github.com/VKCOM/noverify/src/quickfix"
+	"github.com/VKCOM/noverify/src/textdiff"
+	"github.com/VKCOM/noverify/src/workspace"
+)
+
+// rewriteCheckName is a name of the rule that is created from the rewrite pattern.
+const rewriteCheckName = "rewrite"
+
+// maxRewriteIterations limits the number of the rewrite passes,
+// since the replacement can produce new matches forever.
+const maxRewriteIterations = 10
+
+// RewriteFlags are the flags of the rewrite command.
+type RewriteFlags struct {
+	PatternFlags
+
+	DryRun bool
+}
+
+func RegisterRewriteFlags(ctx *AppContext) (*flag.FlagSet, *FlagsGroups) {
+	fs, groups := RegisterCheckFlags(ctx)
+	flags := &RewriteFlags{}
+	registerPatternFlags(fs, groups, &flags.PatternFlags)
+
+	fs.BoolVar(&flags.DryRun, "dry-run", false,
+		"Print the unified diff of the replacements instead of changing the files")
+	groups.Add("Pattern", "dry-run")
+
+	ctx.CustomFlags = flags
+	return fs, groups
+}
+
+// Rewrite replaces all code fragments matching the phpgrep pattern.
+//
+// The pattern and replacement are turned into a dynamic rule with
+// the @fix attribute, so they have the same syntax as in the rule files.
+func Rewrite(ctx *AppContext) (int, error) {
+	if len(ctx.ParsedArgs) < 2 {
+		return 1, fmt.Errorf("pattern and replacement are not specified")
+	}
+	pattern, replacement := ctx.ParsedArgs[0], ctx.ParsedArgs[1]
+	ctx.ParsedArgs = ctx.ParsedArgs[2:]
+	if len(ctx.ParsedArgs) == 0 {
+		ctx.ParsedArgs = []string{"."}
+	}
+	if replacement == "" {
+		return 1, fmt.Errorf("replacement is empty")
+	}
+
+	flags := ctx.CustomFlags.(*RewriteFlags)
+	rset, err := parsePatternRule(rewriteCheckName, pattern, replacement, &flags.PatternFlags)
+	if err != nil {
+		return 1, err
+	}
+	addPatternRule(ctx, rset)
+
+	ctx.ParsedFlags.ApplyQuickFixes = true
+	ctx.ParsedFlags.rewrite = true
+	if ctx.ParsedFlags.Output == "" {
+		ctx.ParsedFlags.Output = "stdout"
+	}
+
+	return Check(ctx)
+}
+
+// rewriter collects the rewritten files contents between the rewrite passes.
+type rewriter struct {
+	dryRun bool
+
+	mu       sync.Mutex
+	original map[string][]byte
+	current  map[string][]byte
+	changed  map[string]bool
+}
+
+// applyFixes is used as linter.Config.QuickFixHandler.
+func (rw *rewriter) applyFixes(filename string, contents []byte, fixes []quickfix.TextEdit) error {
+	fixed := quickfix.Render(contents, fixes)
+	if bytes.Equal(fixed, contents) {
+		return nil
+	}
+
+	if !rw.dryRun {
+		if err := quickfix.Apply(filename, contents, fixes); err != nil {
+			return err
+		}
+	}
+
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if _, ok := rw.original[filename]; !ok {
+		rw.original[filename] = append([]byte(nil), contents...)
+	}
+	rw.current[filename] = fixed
+	rw.changed[filename] = true
+	return nil
+}
+
+// readChanged returns the files changed by the last pass with their current contents.
+func (rw *rewriter) readChanged() workspace.ReadCallback {
+	changed := rw.changed
+	rw.changed = make(map[string]bool)
+	return func(ch chan workspace.FileInfo) {
+		for filename := range changed {
+			ch <- workspace.FileInfo{Name: filename, Contents: rw.current[filename]}
+		}
+	}
+}
+
+// rewriteMain applies the rewrite rule fixes to the files.
+//
+// Nested matches are not replaced in one pass, so the changed files are
+// analyzed again until there is nothing to replace.
+func rewriteMain(runner *LinterRunner, ctx *AppContext) (status int, err error) {
+	flags := ctx.CustomFlags.(*RewriteFlags)
+	rw := &rewriter{
+		dryRun:   flags.DryRun,
+		original: make(map[string][]byte),
+		current:  make(map[string][]byte),
+		changed:  make(map[string]bool),
+	}
+	runner.config.QuickFixHandler = rw.applyFixes
+
+	filenames := ctx.ParsedArgs
+
+	start := time.Now()
+	log.Printf("Indexing %+v", filenames)
+	runner.linter.AnalyzeFiles(workspace.ReadFilenames(filenames, nil, runner.config.PhpExtensions))
+	parseIndexOnlyFiles(runner)
+	runner.linter.MetaInfo().SetIndexingComplete(true)
+	log.Printf("Indexing complete in %s", time.Since(start))
+
+	start = time.Now()
+	read := workspace.ReadFilenames(filenames, runner.filenameFilter, runner.config.PhpExtensions)
+	for i := 1; ; i++ {
+		runner.linter.AnalyzeFiles(read)
+		if len(rw.changed) == 0 {
+			break
+		}
+		if i == maxRewriteIterations {
+			log.Printf("Stopped after %d passes, the replacement may produce new matches", i)
+			break
+		}
+		read = rw.readChanged()
+	}
+	log.Printf("Rewritten %d files in %s", len(rw.current), time.Since(start))
+
+	changedFiles := make([]string, 0, len(rw.current))
+	for filename := range rw.current {
+		changedFiles = append(changedFiles, filename)
+	}
+	sort.Strings(changedFiles)
+
+	for _, filename := range changedFiles {
+		name := baseline.RelativePath(runner.projectRoot, filename)
+		if !flags.DryRun {
+			fmt.Fprintln(runner.outputFp, name)
+			continue
+		}
+		diff := textdiff.Unified("a/"+name, "b/"+name, string(rw.original[filename]), string(rw.current[filename]))
+		fmt.Fprint(runner.outputFp, diff)
+	}
+
+	return 0, nil
+}
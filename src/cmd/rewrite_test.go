@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const rewriteTestFile = `<?php
+function f($x) { return $x; }
+function g($x) { return $x; }
+echo f(f(1));
+echo f(2);
+`
+
+func TestRewriteNestedMatches(t *testing.T) {
+	dir := writeRewriteTestFiles(t)
+
+	// The inner call is only replaced on the second pass,
+	// since the outer replacement overlaps it.
+	out := runRewrite(t, dir, "rewrite", "f($x)", "g($x)", ".")
+	if out != "a.php\n" {
+		t.Errorf("output: have %q, want %q", out, "a.php\n")
+	}
+
+	want := strings.ReplaceAll(strings.ReplaceAll(rewriteTestFile, "echo f(f(1))", "echo g(g(1))"), "echo f(2)", "echo g(2)")
+	if have := readRewriteTestFile(t, dir, "a.php"); have != want {
+		t.Errorf("a.php:\nhave:\n%s\nwant:\n%s", have, want)
+	}
+	if have := readRewriteTestFile(t, dir, "b.php"); have != "<?php\necho 3;\n" {
+		t.Errorf("unchanged b.php is rewritten:\n%s", have)
+	}
+}
+
+func TestRewriteDryRun(t *testing.T) {
+	dir := writeRewriteTestFiles(t)
+
+	want := `--- a/a.php
++++ b/a.php
+@@ -1,5 +1,5 @@
+ <?php
+ function f($x) { return $x; }
+ function g($x) { return $x; }
+-echo f(f(1));
+-echo f(2);
++echo g(g(1));
++echo g(2);
+`
+	if have := runRewrite(t, dir, "rewrite", "--dry-run", "f($x)", "g($x)", "."); have != want {
+		t.Errorf("dry run output:\nhave:\n%s\nwant:\n%s", have, want)
+	}
+	if have := readRewriteTestFile(t, dir, "a.php"); have != rewriteTestFile {
+		t.Errorf("dry run changed a.php:\n%s", have)
+	}
+}
+
+func TestRewriteIterationsLimit(t *testing.T) {
+	dir := writeRewriteTestFiles(t)
+
+	// Every pass produces a new match, so only
+	// maxRewriteIterations calls are added.
+	runRewrite(t, dir, "rewrite", "f($x)", "f(f($x))", ".")
+
+	have := readRewriteTestFile(t, dir, "a.php")
+	wrap := func(n int, arg string) string {
+		return strings.Repeat("f(", n) + arg + strings.Repeat(")", n)
+	}
+	for _, stmt := range []string{
+		"echo " + wrap(2+maxRewriteIterations, "1") + ";",
+		"echo " + wrap(1+maxRewriteIterations, "2") + ";",
+	} {
+		if !strings.Contains(have, stmt) {
+			t.Errorf("a.php doesn't contain %s:\n%s", stmt, have)
+		}
+	}
+}
+
+func TestRewriteDiffModes(t *testing.T) {
+	dir := writeRewriteTestFiles(t)
+
+	for _, flag := range []string{"--git=.git", "--diff-file=a.diff"} {
+		_, err := runMain(t, dir, "rewrite", flag, "f($x)", "g($x)", ".")
+		if err == nil || !strings.Contains(err.Error(), "rewrite can't be used with --git or --diff-file") {
+			t.Errorf("%s: unexpected error: %v", flag, err)
+		}
+	}
+	if have := readRewriteTestFile(t, dir, "a.php"); have != rewriteTestFile {
+		t.Errorf("a.php is changed:\n%s", have)
+	}
+}
+
+func writeRewriteTestFiles(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.php": rewriteTestFile,
+		"b.php": "<?php\necho 3;\n",
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func readRewriteTestFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// runRewrite runs the command in dir and returns its output.
+func runRewrite(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	output := filepath.Join(t.TempDir(), "output.txt")
+	status, err := runMain(t, dir, append([]string{args[0], "--output=" + output}, args[1:]...)...)
+	if err != nil || status != 0 {
+		t.Fatalf("%v: status %d, error %v", args, status, err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// runMain runs the noverify command with the args in dir.
+func runMain(t *testing.T, dir string, args ...string) (int, error) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd) // nolint:errcheck
+
+	osArgs := os.Args
+	defer func() { os.Args = osArgs }()
+	os.Args = append([]string{"noverify"}, args...)
+
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	return Run(&MainConfig{})
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRulePackNameConflict(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"rules/user.php": `<?php
+/**
+ * @comment Report the user-defined unsafe calls.
+ */
+function unsafeUnserialize() {
+  /**
+   * @warning Don't call legacy_unserialize
+   */
+  legacy_unserialize(${"*"});
+}
+`,
+		"src/a.php": `<?php
+function legacy_unserialize($s) { return $s; }
+legacy_unserialize('');
+`,
+	}
+	for name, contents := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	output := filepath.Join(t.TempDir(), "output.txt")
+
+	// The pack rules are not declared unless the pack is enabled,
+	// so the user rule can have the same name.
+	_, err := runMain(t, dir, "check", "--output="+output, "--rules=rules/user.php", "--allow-checks=unsafeUnserialize", "src")
+	if err != nil {
+		t.Fatalf("check without packs: %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "unsafeUnserialize: Don't call legacy_unserialize") {
+		t.Errorf("the user rule is not reported:\n%s", data)
+	}
+
+	_, err = runMain(t, dir, "check", "--output="+output, "--rules=rules/user.php", "--rule-packs=security", "src")
+	want := "rule pack security: the unsafeUnserialize rule name is already used by another checker"
+	if err == nil || err.Error() != want {
+		t.Errorf("check with the security pack: have error %v, want %q", err, want)
+	}
+}
+
+func TestRuleNameConflict(t *testing.T) {
+	dir := t.TempDir()
+	rules := `<?php
+/**
+ * @comment Redeclares the embedded rule.
+ */
+function strictCmp() {
+  /**
+   * @warning Don't call f
+   */
+  f();
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "rules.php"), []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := runMain(t, dir, "check", "--rules=rules.php", ".")
+	want := "preload external rules: the strictCmp rule name is already used by another checker, use @extends to extend it"
+	if err == nil || err.Error() != want {
+		t.Errorf("have error %v, want %q", err, want)
+	}
+}
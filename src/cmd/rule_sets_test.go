@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+	"github.com/VKCOM/noverify/src/rules"
+)
+
+func runWithRuleSet(t *testing.T, spec rules.RuleSpec) (*MainConfig, error) {
+	t.Helper()
+
+	b := rules.NewBuilder()
+	if err := b.Add(spec); err != nil {
+		t.Fatalf("add rule: %v", err)
+	}
+
+	osArgs := os.Args
+	defer func() { os.Args = osArgs }()
+	os.Args = []string{"noverify", "version"}
+
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	cfg := &MainConfig{RuleSets: []*rules.Set{b.Build()}}
+	_, err := Run(cfg)
+	return cfg, err
+}
+
+func TestRunRuleSets(t *testing.T) {
+	cfg, err := runWithRuleSet(t, rules.RuleSpec{
+		Name:    "intvalCall",
+		Pattern: `intval($x)`,
+		Level:   lintapi.LevelNotice,
+		Message: "use (int) cast instead",
+		Fix:     `(int)$x`,
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !cfg.LinterConfig.Checkers.Autofixable("intvalCall") {
+		t.Errorf("intvalCall: the rule with a fix is not autofixable")
+	}
+}
+
+func TestRunRuleSetsNameConflict(t *testing.T) {
+	_, err := runWithRuleSet(t, rules.RuleSpec{
+		Name:    "unused",
+		Pattern: `intval($x)`,
+		Level:   lintapi.LevelNotice,
+		Message: "use (int) cast instead",
+	})
+	want := "the unused rule name is already used by another checker, use @extends to extend it"
+	if err == nil || err.Error() != want {
+		t.Errorf("have error %v, want %q", err, want)
+	}
+}
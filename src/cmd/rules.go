@@ -3,11 +3,13 @@ package cmd
 import (
 	"bytes"
 	"embed"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/linter"
 	"github.com/VKCOM/noverify/src/rules"
 )
 
@@ -29,7 +31,6 @@ func AddEmbeddedRules(rset *rules.Set, filter func(r rules.Rule) bool) ([]*rules
 
 func ParseEmbeddedRules() ([]*rules.Set, error) {
 	var ruleSets []*rules.Set
-	p := rules.NewParser()
 
 	entries, err := embeddedRulesData.ReadDir("embeddedrules")
 	if err != nil {
@@ -37,23 +38,159 @@ func ParseEmbeddedRules() ([]*rules.Set, error) {
 	}
 
 	for _, entry := range entries {
-		filename := filepath.ToSlash(filepath.Join("embeddedrules", entry.Name()))
-		data, err := embeddedRulesData.ReadFile(filename)
-		if err != nil {
-			return nil, err
+		// Rule packs are loaded separately, see ParseEmbeddedRulePacks.
+		if entry.IsDir() {
+			continue
 		}
 
-		rset, err := p.Parse(entry.Name(), bytes.NewReader(data))
+		rset, err := parseEmbeddedRuleFile(entry.Name(), filepath.ToSlash(filepath.Join("embeddedrules", entry.Name())))
 		if err != nil {
 			return nil, err
 		}
-		rset.Builtin = true
 		ruleSets = append(ruleSets, rset)
 	}
 
 	return ruleSets, nil
 }
 
+// RulePack is an optional set of the embedded rules
+// that is enabled by the --rule-packs flag.
+type RulePack struct {
+	Name    string
+	Version string
+	Comment string
+
+	Rules *rules.Set
+}
+
+// embeddedRulePacks lists the rule packs from the embeddedrules/packs folder,
+// every pack rules are stored in the <name>.php file.
+//
+// Pack version should be bumped whenever its rules are added, removed
+// or start to report different things.
+var embeddedRulePacks = []RulePack{
+	{
+		Name:    "security",
+		Version: "1.0.0",
+		Comment: "Potentially unsafe function calls and handling of the user input",
+	},
+	{
+		Name:    "performance",
+		Version: "1.0.0",
+		Comment: "Code patterns with a cheaper alternative",
+	},
+	{
+		Name:    "php8-migration",
+		Version: "1.0.0",
+		Comment: "Code that can be simplified or must be changed for PHP 8",
+	},
+	{
+		Name:    "phpunit",
+		Version: "1.0.0",
+		Comment: "PHPUnit assertions that have a more specific alternative",
+	},
+	{
+		Name:    "kphp",
+		Version: "1.0.0",
+		Comment: "PHP features that are not supported by KPHP",
+	},
+}
+
+// ParseEmbeddedRulePacks parses all rule packs that are shipped with the linter.
+func ParseEmbeddedRulePacks() ([]*RulePack, error) {
+	packs := make([]*RulePack, 0, len(embeddedRulePacks))
+
+	for _, info := range embeddedRulePacks {
+		filename := "embeddedrules/packs/" + info.Name + ".php"
+		rset, err := parseEmbeddedRuleFile(info.Name+".php", filename)
+		if err != nil {
+			return nil, fmt.Errorf("rule pack %s: %v", info.Name, err)
+		}
+		rset.Pack = info.Name
+
+		pack := info
+		pack.Rules = rset
+		packs = append(packs, &pack)
+	}
+
+	return packs, nil
+}
+
+// selectRulePacks returns the rule sets of the packs from
+// the comma-separated list of pack names.
+func selectRulePacks(packs []*RulePack, list string) ([]*rules.Set, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	byName := make(map[string]*RulePack, len(packs))
+	names := make([]string, 0, len(packs))
+	for _, pack := range packs {
+		byName[pack.Name] = pack
+		names = append(names, pack.Name)
+	}
+
+	var ruleSets []*rules.Set
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || selected[name] {
+			continue
+		}
+		pack, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule pack %s, available packs: %s", name, strings.Join(names, ", "))
+		}
+		selected[name] = true
+		ruleSets = append(ruleSets, pack.Rules)
+	}
+
+	return ruleSets, nil
+}
+
+// declareRulePacks declares the rules of all packs,
+// so they're shown by the checkers documentation commands.
+func declareRulePacks(checkers *linter.CheckersRegistry, packs []*RulePack) error {
+	for _, pack := range packs {
+		if err := declareRules(checkers, pack.Rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// declareRules is like CheckersRegistry.DeclareRules, but it
+// returns an error if some rule name is already taken.
+func declareRules(checkers *linter.CheckersRegistry, rset *rules.Set) error {
+	for _, name := range rset.Names {
+		if !checkers.Contains(name) || rset.DocByName[name].Extends {
+			continue
+		}
+		if rset.Pack != "" {
+			return fmt.Errorf("rule pack %s: the %s rule name is already used by another checker", rset.Pack, name)
+		}
+		return fmt.Errorf("the %s rule name is already used by another checker, use @extends to extend it", name)
+	}
+
+	checkers.DeclareRules(rset)
+	return nil
+}
+
+func parseEmbeddedRuleFile(name, filename string) (*rules.Set, error) {
+	data, err := embeddedRulesData.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	rset, err := rules.NewParser().Parse(name, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	rset.Builtin = true
+
+	return rset, nil
+}
+
 func ParseExternalRules(externalRules string) ([]*rules.Set, error) {
 	if externalRules == "" {
 		return nil, nil
@@ -124,6 +261,11 @@ func appendRuleSet(dstSet *rules.Set, srcSet *rules.Set, filter func(r rules.Rul
 				dst.Add(ir.NodeKind(kind), rule)
 			}
 		}
+		for _, rule := range src.Sequences {
+			if filter(rule) {
+				dst.AddSequence(rule)
+			}
+		}
 	}
 	appendRules(dstSet.Any, srcSet.Any)
 	appendRules(dstSet.Root, srcSet.Root)
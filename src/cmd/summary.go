@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/VKCOM/noverify/src/linter"
+)
+
+// printReportsSummary logs the number of reports per group.
+//
+// groupsOf returns the groups of the report, it can belong to several
+// groups at once; reports without groups are counted as "<unknown>".
+func printReportsSummary(runner *LinterRunner, reports []*linter.Report, groupName string, groupsOf func(*linter.Report) []string) {
+	type groupStats struct {
+		name     string
+		all      int
+		critical int
+	}
+
+	byGroup := make(map[string]*groupStats)
+	for _, r := range reports {
+		groups := groupsOf(r)
+		if len(groups) == 0 || (len(groups) == 1 && groups[0] == "") {
+			groups = []string{"<unknown>"}
+		}
+		for _, name := range groups {
+			stats, ok := byGroup[name]
+			if !ok {
+				stats = &groupStats{name: name}
+				byGroup[name] = stats
+			}
+			stats.all++
+			if runner.checkersFilter.IsCriticalReport(r) {
+				stats.critical++
+			}
+		}
+	}
+	if len(byGroup) == 0 {
+		return
+	}
+
+	list := make([]*groupStats, 0, len(byGroup))
+	for _, stats := range byGroup {
+		list = append(list, stats)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].all != list[j].all {
+			return list[i].all > list[j].all
+		}
+		return list[i].name < list[j].name
+	})
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tReports\tCritical\n", groupName)
+	for _, stats := range list {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", stats.name, stats.all, stats.critical)
+	}
+	w.Flush()
+
+	log.Printf("Reports per %s:\n%s", strings.ToLower(groupName), buf.String())
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"log"
@@ -10,8 +11,11 @@ import (
 	"strings"
 
 	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irutil"
 	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/quickfix"
 	"github.com/VKCOM/noverify/src/rules"
+	"github.com/VKCOM/noverify/src/textdiff"
 	"github.com/VKCOM/noverify/src/utils"
 	"github.com/VKCOM/noverify/src/workspace"
 )
@@ -62,6 +66,30 @@ type RulesTestSuite struct {
 	Tests string
 
 	KPHP bool
+
+	// positive and negative contain the names of the checkers
+	// that have at least one positive or negative test.
+	positive map[string]bool
+	negative map[string]bool
+}
+
+// testFile is a linted test file.
+type testFile struct {
+	// checkerName is a name of the tested checker,
+	// it's empty for the *_any.php files.
+	checkerName string
+
+	lines   []string
+	reports []*linter.Report
+
+	// stmtLines are the lines where the function body statements start,
+	// only these lines can be negative tests.
+	stmtLines map[int]bool
+
+	// fixed is the file contents after applying the quick fixes.
+	// It's nil if the file has no *.fixed.php companion.
+	fixed     []byte
+	fixedFile string
 }
 
 func NewRulesTestSuite(rulesPaths string, tests string, kphp bool) *RulesTestSuite {
@@ -80,9 +108,21 @@ func (s *RulesTestSuite) Run() error {
 		return fmt.Errorf("tests files in '%s' not found", s.Tests)
 	}
 
+	ruleSets, err := ParseExternalRules(s.Rules)
+	if err != nil {
+		return fmt.Errorf("preload external rules: %v", err)
+	}
+
+	s.positive = make(map[string]bool)
+	s.negative = make(map[string]bool)
+
 	failed := false
 
 	for _, file := range files {
+		if isFixedTestFile(file) {
+			continue
+		}
+
 		errs := s.handleFile(file)
 		if len(errs) > 0 {
 			log.Printf("Test '%s' failed\n", file)
@@ -96,6 +136,8 @@ func (s *RulesTestSuite) Run() error {
 		}
 	}
 
+	s.printCoverage(ruleSets)
+
 	if failed {
 		return fmt.Errorf("test failed")
 	}
@@ -104,14 +146,190 @@ func (s *RulesTestSuite) Run() error {
 
 // handleFile processes a file from the list of php files found in the directory.
 func (s *RulesTestSuite) handleFile(file string) (errs []error) {
-	lines, reports, err := s.handleFileContents(file)
+	f, err := s.handleFileContents(file)
 	if err != nil {
 		return []error{err}
 	}
 
-	reportsByLine := s.createReportsByLine(reports)
+	reportsByLine := s.createReportsByLine(f.reports)
+
+	errs = s.handleLines(f.lines, reportsByLine)
+
+	if f.fixed != nil {
+		err := s.compareFixed(f)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		s.updateCoverage(f)
+	}
+
+	return errs
+}
+
+// compareFixed compares the file contents after applying
+// the quick fixes with the contents of the *.fixed.php file.
+func (s *RulesTestSuite) compareFixed(f *testFile) error {
+	want, err := os.ReadFile(f.fixedFile)
+	if err != nil {
+		return fmt.Errorf("error read file '%s': %v", f.fixedFile, err)
+	}
 
-	return s.handleLines(lines, reportsByLine)
+	if bytes.Equal(f.fixed, want) {
+		return nil
+	}
+
+	diff := textdiff.Unified(f.fixedFile, "fixed", string(want), string(f.fixed))
+	return fmt.Errorf("quick fixes result doesn't match '%s':\n%s", f.fixedFile, diff)
+}
+
+// updateCoverage marks the checkers that are covered by the passed test file.
+//
+// A positive test is a report that is matched by the `want` expectation.
+// A negative test is a function body statement without expectations and reports
+// in the test file that is named after the checker, so *_any.php files have only
+// positive tests.
+func (s *RulesTestSuite) updateCoverage(f *testFile) {
+	reportLines := make(map[int]bool, len(f.reports))
+	for _, r := range f.reports {
+		reportLines[r.Line] = true
+		if r.Line <= 0 || r.Line > len(f.lines) {
+			continue
+		}
+		expects, err := s.getExpectationForLine(f.lines[r.Line-1], r.Line-1)
+		if err != nil {
+			continue
+		}
+		if len(s.compare(expects, []string{r.Message})) < len(expects) {
+			s.positive[r.CheckName] = true
+		}
+	}
+
+	if f.checkerName == "" {
+		return
+	}
+
+	for index, line := range f.lines {
+		if !f.stmtLines[index+1] || reportLines[index+1] {
+			continue
+		}
+		expects, err := s.getExpectationForLine(line, index)
+		if err == nil && expects == nil {
+			s.negative[f.checkerName] = true
+			break
+		}
+	}
+}
+
+// printCoverage prints the rules that have no positive or negative tests.
+func (s *RulesTestSuite) printCoverage(ruleSets []*rules.Set) {
+	uncovered := s.uncoveredRules(ruleSets)
+	if len(uncovered) == 0 {
+		log.Println("All rules have positive and negative tests")
+		return
+	}
+
+	log.Println("Rules coverage:")
+	for _, line := range uncovered {
+		log.Printf("   %s\n", line)
+	}
+}
+
+// uncoveredRules describes the rules that have no positive or negative tests.
+func (s *RulesTestSuite) uncoveredRules(ruleSets []*rules.Set) []string {
+	var uncovered []string
+
+	for _, rset := range ruleSets {
+		for _, name := range rset.Names {
+			var missing []string
+			if !s.positive[name] {
+				missing = append(missing, "positive")
+			}
+			if !s.negative[name] {
+				missing = append(missing, "negative")
+			}
+			if len(missing) != 0 {
+				uncovered = append(uncovered, fmt.Sprintf("%s: no %s test", name, strings.Join(missing, " and no ")))
+			}
+		}
+	}
+
+	return uncovered
+}
+
+// bodyStmtLines returns the lines where the function body statements start.
+// The nested function and class declarations are not statements of the body,
+// so their header lines are not included.
+func bodyStmtLines(root ir.Node) map[int]bool {
+	lines := make(map[int]bool)
+
+	addStmts := func(stmts []ir.Node) {
+		for _, st := range stmts {
+			switch st.(type) {
+			case *ir.FunctionStmt, *ir.ClassStmt, *ir.InterfaceStmt, *ir.TraitStmt:
+				continue
+			}
+			if pos := ir.GetPosition(st); pos != nil {
+				lines[pos.StartLine] = true
+			}
+		}
+	}
+	addBody := func(stmts []ir.Node) {
+		addStmts(stmts)
+		for _, st := range stmts {
+			irutil.Inspect(st, func(n ir.Node) bool {
+				switch n := n.(type) {
+				case *ir.FunctionStmt, *ir.ClosureExpr, *ir.ArrowFunctionExpr, *ir.ClassStmt, *ir.AnonClassExpr:
+					// Their bodies are handled separately.
+					return false
+				case *ir.StmtList:
+					addStmts(n.Stmts)
+				case *ir.CaseStmt:
+					addStmts(n.Stmts)
+				case *ir.DefaultStmt:
+					addStmts(n.Stmts)
+				case *ir.TryStmt:
+					addStmts(n.Stmts)
+				case *ir.CatchStmt:
+					addStmts(n.Stmts)
+				case *ir.FinallyStmt:
+					addStmts(n.Stmts)
+				}
+				return true
+			})
+		}
+	}
+
+	irutil.Inspect(root, func(n ir.Node) bool {
+		switch n := n.(type) {
+		case *ir.FunctionStmt:
+			addBody(n.Stmts)
+		case *ir.ClosureExpr:
+			addBody(n.Stmts)
+		case *ir.ClassMethodStmt:
+			if body, ok := n.Stmt.(*ir.StmtList); ok {
+				addBody(body.Stmts)
+			}
+		}
+		return true
+	})
+
+	return lines
+}
+
+// isFixedTestFile reports whether the file contains the expected
+// quick fixes result for another test file, like foo.fixed.php.
+func isFixedTestFile(file string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(file, filepath.Ext(file)), ".fixed")
+}
+
+// fixedTestFile returns the name of the file with the expected
+// quick fixes result for the test file.
+func fixedTestFile(file string) string {
+	ext := filepath.Ext(file)
+	return strings.TrimSuffix(file, ext) + ".fixed" + ext
 }
 
 func (s *RulesTestSuite) handleLines(lines []string, reportsByLine map[int][]string) (errs []error) {
@@ -189,7 +407,10 @@ func (s *RulesTestSuite) compare(expects []string, reports []string) (unmatched
 }
 
 // handleFileContents reads, parses the resulting file, and splits it into lines.
-func (s *RulesTestSuite) handleFileContents(file string) (lines []string, reports []*linter.Report, err error) {
+//
+// If the file has a *.fixed.php companion, the quick fixes of
+// the tested checker are applied to the file contents as well.
+func (s *RulesTestSuite) handleFileContents(file string) (*testFile, error) {
 	rawCheckerName := file
 	lint := linter.NewLinter(linter.NewConfig("8.1"))
 	if strings.Contains(file, "_7.4") {
@@ -197,16 +418,17 @@ func (s *RulesTestSuite) handleFileContents(file string) (lines []string, report
 		rawCheckerName = strings.ReplaceAll(file, "_7.4", "")
 	}
 
-	runner := NewLinterRunner(lint, linter.NewCheckersFilterWithEnabledAll())
+	checkersFilter := linter.NewCheckersFilterWithEnabledAll()
+	runner := NewLinterRunner(lint, checkersFilter)
 
-	err = InitStubs(lint)
+	err := InitStubs(lint)
 	if err != nil {
-		return nil, nil, fmt.Errorf("load stubs: %v", err)
+		return nil, fmt.Errorf("load stubs: %v", err)
 	}
 
 	err = s.initEmbeddedRules(lint.Config())
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	config := lint.Config()
@@ -214,7 +436,7 @@ func (s *RulesTestSuite) handleFileContents(file string) (lines []string, report
 
 	ruleSets, err := ParseExternalRules(s.Rules)
 	if err != nil {
-		return nil, nil, fmt.Errorf("preload external rules: %v", err)
+		return nil, fmt.Errorf("preload external rules: %v", err)
 	}
 
 	for _, rset := range ruleSets {
@@ -230,12 +452,12 @@ func (s *RulesTestSuite) handleFileContents(file string) (lines []string, report
 		UnusedVarPattern: "^_$",
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("runner init fail: %v", err)
+		return nil, fmt.Errorf("runner init fail: %v", err)
 	}
 
 	data, err := os.ReadFile(file)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error read file '%s': %v", file, err)
+		return nil, fmt.Errorf("error read file '%s': %v", file, err)
 	}
 
 	content := string(data)
@@ -254,7 +476,11 @@ func (s *RulesTestSuite) handleFileContents(file string) (lines []string, report
 		}
 	}
 
-	lines = strings.Split(content, "\n")
+	f := &testFile{
+		lines:     strings.Split(content, "\n"),
+		reports:   res.Reports,
+		stmtLines: bodyStmtLines(res.RootNode),
+	}
 
 	checkerName := filepath.Base(rawCheckerName)
 	checkerName = checkerName[:len(checkerName)-len(filepath.Ext(file))]
@@ -262,13 +488,31 @@ func (s *RulesTestSuite) handleFileContents(file string) (lines []string, report
 
 	if !strings.HasSuffix(checkerName, "_any") {
 		if !lint.Config().Checkers.Contains(checkerName) {
-			return nil, nil, fmt.Errorf("file name with namespace inside must be the name of the checker that is tested. Checker '%s' does not exist", checkerName)
+			return nil, fmt.Errorf("file name with namespace inside must be the name of the checker that is tested. Checker '%s' does not exist", checkerName)
 		}
 
-		return lines, s.filterReports([]string{checkerName}, res.Reports), nil
+		f.checkerName = checkerName
+		f.reports = s.filterReports([]string{checkerName}, res.Reports)
+
+		// Only the tested checker quick fixes should be applied.
+		checkersFilter.EnableAll = false
+		checkersFilter.Allowed = map[string]bool{checkerName: true}
+	}
+
+	fixedFile := fixedTestFile(file)
+	if _, err := os.Stat(fixedFile); err == nil {
+		f.fixedFile = fixedFile
+		f.fixed = data
+
+		config.ApplyQuickFixes = true
+		config.QuickFixHandler = func(_ string, contents []byte, fixes []quickfix.TextEdit) error {
+			f.fixed = quickfix.Render(contents, fixes)
+			return nil
+		}
+		s.ParseTestFile(lint, file, content)
 	}
 
-	return lines, res.Reports, nil
+	return f, nil
 }
 
 func (s *RulesTestSuite) filterReports(names []string, reports []*linter.Report) []*linter.Report {
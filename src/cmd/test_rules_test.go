@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsFixedTestFile(t *testing.T) {
+	tests := []struct {
+		file  string
+		fixed bool
+	}{
+		{file: "tests/foo.php", fixed: false},
+		{file: "tests/foo.fixed.php", fixed: true},
+		{file: "tests/foo.fixed.inc", fixed: true},
+		{file: "tests/fixed.php", fixed: false},
+		{file: "tests/foo_fixed.php", fixed: false},
+		{file: "tests/foo.fixed/bar.php", fixed: false},
+	}
+
+	for _, test := range tests {
+		if have := isFixedTestFile(test.file); have != test.fixed {
+			t.Errorf("isFixedTestFile(%s): have %v, want %v", test.file, have, test.fixed)
+		}
+	}
+
+	if have := fixedTestFile("tests/foo.php"); have != "tests/foo.fixed.php" {
+		t.Errorf("fixedTestFile: have %s, want tests/foo.fixed.php", have)
+	}
+}
+
+func TestRulesTestSuiteFixed(t *testing.T) {
+	dir := filepath.Join("testdata", "test-rules")
+	suite := NewRulesTestSuite(filepath.Join(dir, "rules.php"), filepath.Join(dir, "tests"), false)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	err := suite.Run()
+	log.SetOutput(os.Stderr)
+
+	if err == nil || err.Error() != "test failed" {
+		t.Fatalf("run: have error %v, want test failed", err)
+	}
+
+	// The *.fixed.php files are not the tests themselves.
+	for _, line := range strings.Split(logs.String(), "\n") {
+		if strings.Contains(line, ".fixed.php' ") {
+			t.Errorf("the fixed file is handled as a test: %s", line)
+		}
+	}
+
+	if errs := suite.handleFile(filepath.Join(dir, "tests", "strvalCall.php")); len(errs) != 0 {
+		t.Errorf("strvalCall: unexpected errors: %v", errs)
+	}
+
+	errs := suite.handleFile(filepath.Join(dir, "tests", "intvalCall.php"))
+	if len(errs) != 1 {
+		t.Fatalf("intvalCall: have %d errors, want 1: %v", len(errs), errs)
+	}
+	for _, s := range []string{
+		"quick fixes result doesn't match",
+		"-  $_ = (integer)$x; // want",
+		"+  $_ = (int)$x; // want",
+	} {
+		if !strings.Contains(errs[0].Error(), s) {
+			t.Errorf("intvalCall: the error doesn't contain %q:\n%v", s, errs[0])
+		}
+	}
+
+	ruleSets, err := ParseExternalRules(suite.Rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"boolvalCall: no negative test",
+		"floatvalCall: no negative test",
+		"intvalCall: no positive and no negative test",
+	}
+	if diff := cmp.Diff(want, suite.uncoveredRules(ruleSets)); diff != "" {
+		t.Errorf("uncovered rules (-want +have):\n%s", diff)
+	}
+}
+
+func TestRulesTestSuiteCoverage(t *testing.T) {
+	suite := &RulesTestSuite{
+		positive: map[string]bool{"a": true, "b": true},
+		negative: map[string]bool{"a": true, "c": true},
+	}
+	ruleSets, err := ParseExternalRules(filepath.Join("testdata", "test-rules", "rules.php"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ruleSets[0].Names = []string{"a", "b", "c", "d"}
+
+	want := []string{
+		"b: no negative test",
+		"c: no positive test",
+		"d: no positive and no negative test",
+	}
+	if diff := cmp.Diff(want, suite.uncoveredRules(ruleSets)); diff != "" {
+		t.Errorf("uncovered rules (-want +have):\n%s", diff)
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	suite.printCoverage(ruleSets[:0])
+	log.SetOutput(os.Stderr)
+	if !strings.Contains(logs.String(), "All rules have positive and negative tests") {
+		t.Errorf("unexpected coverage output: %s", logs.String())
+	}
+}
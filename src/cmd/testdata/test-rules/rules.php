@@ -0,0 +1,43 @@
+<?php
+
+/**
+ * @comment Report the strval calls.
+ */
+function strvalCall() {
+  /**
+   * @warning Use a string cast instead of strval
+   * @fix (string)$x
+   */
+  strval($x);
+}
+
+/**
+ * @comment Report the intval calls.
+ */
+function intvalCall() {
+  /**
+   * @warning Use an int cast instead of intval
+   * @fix (int)$x
+   */
+  intval($x);
+}
+
+/**
+ * @comment Report the boolval calls.
+ */
+function boolvalCall() {
+  /**
+   * @warning Use a bool cast instead of boolval
+   */
+  boolval($x);
+}
+
+/**
+ * @comment Report the floatval calls.
+ */
+function floatvalCall() {
+  /**
+   * @warning Use a float cast instead of floatval
+   */
+  floatval($x);
+}
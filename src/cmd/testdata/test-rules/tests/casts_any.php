@@ -0,0 +1,5 @@
+<?php
+
+function g($x) {
+  $_ = boolval($x); // want `Use a bool cast instead of boolval`
+}
@@ -0,0 +1,11 @@
+<?php
+
+function f($x) {
+  $_ = floatval($x); // want `Use a float cast instead of floatval`
+}
+
+class Number {
+  public function value($x) {
+    return floatval($x); // want `Use a float cast instead of floatval`
+  }
+}
@@ -0,0 +1,6 @@
+<?php
+
+function f($x) {
+  $_ = (integer)$x; // want `Use an int cast instead of intval`
+  $_ = (int)$x;
+}
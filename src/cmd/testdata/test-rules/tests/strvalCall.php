@@ -0,0 +1,6 @@
+<?php
+
+function f($x) {
+  $_ = strval($x); // want `Use a string cast instead of strval`
+  $_ = (string)$x;
+}
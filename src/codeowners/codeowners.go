@@ -0,0 +1,235 @@
+// Package codeowners implements the CODEOWNERS file parsing and matching.
+//
+// Both GitHub and GitLab syntaxes are supported, including the GitLab sections.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Locations is a list of the project root relative paths
+// where the CODEOWNERS file is searched.
+var Locations = []string{
+	"CODEOWNERS",
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	sections []*section
+}
+
+type section struct {
+	rules []rule
+}
+
+type rule struct {
+	re     *regexp.Regexp
+	owners []string
+}
+
+// Find returns the path of the CODEOWNERS file inside the root directory,
+// or an empty string if there is no such file.
+func Find(root string) string {
+	for _, location := range Locations {
+		filename := filepath.Join(root, filepath.FromSlash(location))
+		if _, err := os.Stat(filename); err == nil {
+			return filename
+		}
+	}
+	return ""
+}
+
+// ParseFile reads and parses the CODEOWNERS file.
+func ParseFile(filename string) (*File, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses the CODEOWNERS file contents.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+	cur := &section{}
+	file.sections = append(file.sections, cur)
+	var sectionOwners []string
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// GitLab section header: [Section name][2] @default-owner
+		// Optional sections are prefixed with "^".
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			owners, err := parseSectionOwners(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNum, err)
+			}
+			cur = &section{}
+			file.sections = append(file.sections, cur)
+			sectionOwners = owners
+			continue
+		}
+
+		fields := splitFields(line)
+		pattern := fields[0]
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = sectionOwners
+		}
+
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: pattern %s: %v", lineNum, pattern, err)
+		}
+		cur.rules = append(cur.rules, rule{re: re, owners: owners})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Owners returns the owners of the file with the given slash-separated
+// path relative to the project root.
+//
+// Inside every section, the last matching rule wins.
+// Owners from all sections are combined.
+func (f *File) Owners(filename string) []string {
+	filename = strings.TrimPrefix(filename, "/")
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, s := range f.sections {
+		for i := len(s.rules) - 1; i >= 0; i-- {
+			r := s.rules[i]
+			if !r.re.MatchString(filename) {
+				continue
+			}
+			for _, owner := range r.owners {
+				if !seen[owner] {
+					seen[owner] = true
+					result = append(result, owner)
+				}
+			}
+			break
+		}
+	}
+	return result
+}
+
+// parseSectionOwners returns the default owners of the section.
+func parseSectionOwners(line string) ([]string, error) {
+	line = strings.TrimPrefix(line, "^")
+	end := strings.IndexByte(line, ']')
+	if end < 0 {
+		return nil, fmt.Errorf("unterminated section header")
+	}
+	rest := line[end+1:]
+
+	// Skip the required approvals count, like [2].
+	if strings.HasPrefix(rest, "[") {
+		countEnd := strings.IndexByte(rest, ']')
+		if countEnd < 0 {
+			return nil, fmt.Errorf("unterminated approvals count")
+		}
+		rest = rest[countEnd+1:]
+	}
+
+	return strings.Fields(rest), nil
+}
+
+// splitFields is like strings.Fields, but it respects the escaped spaces.
+func splitFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	escaped := false
+	for _, ch := range line {
+		switch {
+		case escaped:
+			field.WriteRune(ch)
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == ' ' || ch == '\t':
+			if field.Len() != 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(ch)
+		}
+	}
+	if field.Len() != 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// compilePattern converts the gitignore-like pattern to a regexp.
+//
+// A pattern matches the file if it matches the file path itself
+// or any of its parent directories, except for the patterns
+// that end with "/*".
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	// Patterns with a slash (except the trailing one) are relative
+	// to the root, others match at any level.
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var re strings.Builder
+	re.WriteString("^")
+	if !anchored {
+		re.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case ch == '*':
+			re.WriteString("[^/]*")
+		case ch == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	switch {
+	case dirOnly:
+		re.WriteString("/.*$")
+	case pattern == "*" || strings.HasSuffix(pattern, "/*"):
+		// Like in GitHub, "docs/*" matches only the direct children of docs.
+		re.WriteString("$")
+	default:
+		re.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(re.String())
+}
@@ -0,0 +1,106 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOwners(t *testing.T) {
+	const input = `
+# Default owners.
+*                   @global-owner
+
+*.php               @php-team
+/build/logs/        @doghouse
+/build/cache/       @cache-team
+docs/*              docs@example.com
+apps/               @octocat
+**/logs             @logs-team
+/scripts/**/tmp.php @scripts-team
+Foo?.php            @foo-team
+/lib/My\ File.php   @spaces
+/vendor/
+`
+
+	f, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		filename string
+		want     []string
+	}{
+		{"README.md", []string{"@global-owner"}},
+		{"src/index.php", []string{"@php-team"}},
+		{"build/a.txt", []string{"@global-owner"}},
+		{"build/logs/a.txt", []string{"@logs-team"}}, // The last match wins
+		{"build/logs", []string{"@logs-team"}},
+		{"build/logs/deep/a.php", []string{"@logs-team"}},
+		{"docs/a.md", []string{"docs@example.com"}},
+		{"docs/deep/a.md", []string{"@global-owner"}},
+		{"docs/a.php", []string{"docs@example.com"}},
+		{"build/cache/a.txt", []string{"@cache-team"}},
+		{"build/cache", []string{"@global-owner"}}, // Only directory contents
+		{"apps/a.php", []string{"@octocat"}},
+		{"src/apps/a.php", []string{"@octocat"}},
+		{"scripts/tmp.php", []string{"@scripts-team"}},
+		{"scripts/a/b/tmp.php", []string{"@scripts-team"}},
+		{"src/Foo1.php", []string{"@foo-team"}},
+		{"src/Foo12.php", []string{"@php-team"}},
+		{"lib/My File.php", []string{"@spaces"}},
+		{"vendor/a.php", nil},
+		{"/src/index.php", []string{"@php-team"}},
+	}
+
+	for _, test := range tests {
+		have := f.Owners(test.filename)
+		if diff := cmp.Diff(have, test.want); diff != "" {
+			t.Errorf("%s: owners differ:\n%s", test.filename, diff)
+		}
+	}
+}
+
+func TestOwnersSections(t *testing.T) {
+	const input = `
+*.php @php-team
+
+[Docs] @docs-team
+*.md
+/README.md @readme-owner
+
+^[Database][2] @db-team @dba
+migrations/
+`
+
+	f, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		filename string
+		want     []string
+	}{
+		{"a.md", []string{"@docs-team"}},
+		{"README.md", []string{"@readme-owner"}},
+		{"src/migrations/a.php", []string{"@php-team", "@db-team", "@dba"}},
+		{"a.txt", nil},
+	}
+
+	for _, test := range tests {
+		have := f.Owners(test.filename)
+		if diff := cmp.Diff(have, test.want); diff != "" {
+			t.Errorf("%s: owners differ:\n%s", test.filename, diff)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse(strings.NewReader("*.php @team\n[Docs @docs\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected line 2 error, have %v", err)
+	}
+}
@@ -2,7 +2,10 @@ package git
 
 import (
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // BlameResult is the result of git blame operation
@@ -53,3 +56,66 @@ func Blame(gitDir string, refspec []string, filename string) (BlameResult, error
 
 	return res, nil
 }
+
+// LineAuthor describes the last change of the file line.
+type LineAuthor struct {
+	Commit string
+	Author string
+	Time   time.Time
+}
+
+// BlameAuthors returns the last change info for every line of the working tree file.
+// Lines that are not committed yet have a Zero commit.
+func BlameAuthors(filename string) (map[int]LineAuthor, error) {
+	dir, base := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+
+	out, err := execOutput("git", "-C", dir, "--no-pager", "blame", "--line-porcelain", "--", base)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBlamePorcelain(out)
+}
+
+// parseBlamePorcelain parses the `git blame --line-porcelain` output.
+func parseBlamePorcelain(out []byte) (map[int]LineAuthor, error) {
+	// Every line is described by the header "<commit> <orig-line> <line> [<count>]",
+	// followed by the "<key> <value>" lines and the tab-prefixed line contents.
+	res := make(map[int]LineAuthor)
+	var cur LineAuthor
+	var lineNum int
+	for _, ln := range strings.Split(string(out), "\n") {
+		switch {
+		case ln == "":
+			continue
+		case ln[0] == '\t':
+			res[lineNum] = cur
+			cur = LineAuthor{}
+			lineNum = 0
+		case lineNum == 0:
+			fields := strings.Fields(ln)
+			if len(fields) < 3 || len(fields[0]) < CommitHashLen {
+				return nil, fmt.Errorf("bad blame header: %s", ln)
+			}
+			cur.Commit = fields[0]
+			var err error
+			lineNum, err = strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("bad blame header: %s", ln)
+			}
+		case strings.HasPrefix(ln, "author "):
+			cur.Author = strings.TrimPrefix(ln, "author ")
+		case strings.HasPrefix(ln, "author-time "):
+			unixTime, err := strconv.ParseInt(strings.TrimPrefix(ln, "author-time "), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("bad blame author time: %s", ln)
+			}
+			cur.Time = time.Unix(unixTime, 0).UTC()
+		}
+	}
+
+	return res, nil
+}
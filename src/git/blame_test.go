@@ -0,0 +1,81 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseBlamePorcelain(t *testing.T) {
+	const (
+		commitA = "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"
+		commitB = "7c4a8d09ca3762af61e59520943dc26494f8941b"
+	)
+
+	// The same commit header is repeated for every line it owns,
+	// the root commit is marked as a boundary and the uncommitted
+	// lines have the all-zero hash.
+	out := commitA + " 1 1 2\n" +
+		"author Alice\n" +
+		"author-mail <alice@example.com>\n" +
+		"author-time 1600000000\n" +
+		"author-tz +0300\n" +
+		"summary Initial commit\n" +
+		"boundary\n" +
+		"filename a.php\n" +
+		"\t<?php\n" +
+		commitA + " 2 2\n" +
+		"author Alice\n" +
+		"author-mail <alice@example.com>\n" +
+		"author-time 1600000000\n" +
+		"author-tz +0300\n" +
+		"summary Initial commit\n" +
+		"boundary\n" +
+		"filename a.php\n" +
+		"\t\n" +
+		commitB + " 2 3 1\n" +
+		"author Bob Smith\n" +
+		"author-mail <bob@example.com>\n" +
+		"author-time 1700000000\n" +
+		"author-tz +0000\n" +
+		"previous " + commitA + " a.php\n" +
+		"filename a.php\n" +
+		"\tauthor f();\n" +
+		Zero + " 4 4 1\n" +
+		"author Not Committed Yet\n" +
+		"author-mail <not.committed.yet>\n" +
+		"author-time 1800000000\n" +
+		"author-tz +0000\n" +
+		"filename a.php\n" +
+		"\tg();\n"
+
+	have, err := parseBlamePorcelain([]byte(out))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := map[int]LineAuthor{
+		1: {Commit: commitA, Author: "Alice", Time: time.Unix(1600000000, 0).UTC()},
+		2: {Commit: commitA, Author: "Alice", Time: time.Unix(1600000000, 0).UTC()},
+		3: {Commit: commitB, Author: "Bob Smith", Time: time.Unix(1700000000, 0).UTC()},
+		4: {Commit: Zero, Author: "Not Committed Yet", Time: time.Unix(1800000000, 0).UTC()},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected authors (-want +have):\n%s", diff)
+	}
+}
+
+func TestParseBlamePorcelainErrors(t *testing.T) {
+	tests := []string{
+		"a94a8fe 1 1\n\tf();\n",
+		"a94a8fe5ccb19ba61c4c0873d391e987982fbbd3 1\n\tf();\n",
+		"a94a8fe5ccb19ba61c4c0873d391e987982fbbd3 1 x\n\tf();\n",
+		"a94a8fe5ccb19ba61c4c0873d391e987982fbbd3 1 1\nauthor-time now\n\tf();\n",
+	}
+
+	for _, out := range tests {
+		if _, err := parseBlamePorcelain([]byte(out)); err == nil {
+			t.Errorf("%q: expected an error", out)
+		}
+	}
+}
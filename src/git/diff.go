@@ -47,6 +47,10 @@ type Change struct {
 	OldName, NewName          string
 	OldLineRanges, LineRanges []LineRange
 	Valid                     bool
+
+	// AddedLines are the lines added to the new file version.
+	// Unlike LineRanges, they don't include the context lines of the patch hunks.
+	AddedLines []LineRange
 }
 
 // Commit represents git commit :)
@@ -196,9 +200,15 @@ func Log(gitDir string, refspec []string) (res []Commit, err error) {
 	return res, nil
 }
 
+// ParseDiff parses the unified diff, like the one produced by the diff -u or git diff.
+func ParseDiff(r io.Reader) ([]Change, error) {
+	return parseDiff(bufio.NewReader(r))
+}
+
 func parseDiff(rd *bufio.Reader) ([]Change, error) {
 	var res []Change
 	var cur Change
+	var hunk hunkState
 
 	cur.Valid = true
 
@@ -211,6 +221,18 @@ ParsingLoop:
 		case err != nil:
 			return nil, err
 		case skip:
+			if hunk.inside() {
+				hunk.oldLeft--
+				hunk.newLeft--
+				hunk.newLine++
+			}
+			continue
+		}
+
+		// Hunk lines are handled separately, since a removed
+		// line can look like a file header, e.g. "--- comment".
+		if hunk.inside() {
+			cur.parseHunkLine(ln, &hunk)
 			continue
 		}
 
@@ -222,6 +244,7 @@ ParsingLoop:
 				cur.NewName = ""
 				cur.LineRanges = nil
 				cur.OldLineRanges = nil
+				cur.AddedLines = nil
 				cur.Type = 0
 			}
 
@@ -237,6 +260,7 @@ ParsingLoop:
 			if err := cur.parsePatchHeader(trimmed[0:suffixIdx]); err != nil {
 				return nil, err
 			}
+			hunk = newHunkState(trimmed[0:suffixIdx])
 		case bytes.HasPrefix(ln, patchHeaderPrefix3) && bytes.Contains(ln, patchHeaderSuffix3):
 			trimmed := bytes.TrimPrefix(ln, patchHeaderPrefix3)
 			suffixIdx := bytes.Index(trimmed, patchHeaderSuffix3)
@@ -259,7 +283,7 @@ ParsingLoop:
 // --- a/oldfile
 // --- /dev/null
 func (c *Change) parseOld(ln []byte) {
-	c.OldName = string(bytes.TrimPrefix(bytes.TrimPrefix(ln, diffOldPrefix), diffOldNamePrefix))
+	c.OldName = string(bytes.TrimPrefix(bytes.TrimPrefix(trimTimestamp(ln), diffOldPrefix), diffOldNamePrefix))
 	if c.OldName == "/dev/null" {
 		c.Type = Added
 	} else {
@@ -270,12 +294,23 @@ func (c *Change) parseOld(ln []byte) {
 // +++ b/newfile
 // +++ /dev/null
 func (c *Change) parseNew(ln []byte) {
-	c.NewName = string(bytes.TrimPrefix(bytes.TrimPrefix(ln, diffNewPrefix), diffNewNamePrefix))
+	c.NewName = string(bytes.TrimPrefix(bytes.TrimPrefix(trimTimestamp(ln), diffNewPrefix), diffNewNamePrefix))
 	if c.NewName == "/dev/null" {
 		c.Type = Deleted
 	}
 }
 
+// trimTimestamp removes the tab-separated part after the filename,
+// like the modification time that is printed by the diff -u.
+//
+// +++ newfile	2020-07-13 20:58:30.000000000 +0300
+func trimTimestamp(ln []byte) []byte {
+	if tabIdx := bytes.IndexByte(ln, '\t'); tabIdx >= 0 {
+		return ln[:tabIdx]
+	}
+	return ln
+}
+
 // 20433,10
 // 284
 func (c *Change) parseLineRange(toFileRange []byte) (LineRange, error) {
@@ -347,6 +382,81 @@ func (c *Change) parsePatchHeader(ln []byte) error {
 	return nil
 }
 
+// hunkState tracks the position inside the patch hunk.
+type hunkState struct {
+	newLine int // The line number of the next added or context line
+
+	// Number of the old and new file lines left in the hunk.
+	oldLeft int
+	newLeft int
+}
+
+// newHunkState parses the hunk lines counts from the header,
+// like "-20433,10 +20433,12". The state is empty if the header can't be parsed.
+func newHunkState(header []byte) hunkState {
+	fields := bytes.Fields(header)
+	if len(fields) != 2 || !bytes.HasPrefix(fields[0], []byte("-")) || !bytes.HasPrefix(fields[1], []byte("+")) {
+		// Combined diff hunks are not tracked.
+		return hunkState{}
+	}
+	_, oldCount, err1 := parseHunkRange(fields[0][1:])
+	newLine, newCount, err2 := parseHunkRange(fields[1][1:])
+	if err1 != nil || err2 != nil {
+		return hunkState{}
+	}
+	return hunkState{newLine: newLine, oldLeft: oldCount, newLeft: newCount}
+}
+
+func (h *hunkState) inside() bool {
+	return h.oldLeft > 0 || h.newLeft > 0
+}
+
+// parseHunkRange parses "<start>,<count>" or "<start>" hunk range.
+func parseHunkRange(s []byte) (start, count int, err error) {
+	count = 1
+	if commaIdx := bytes.IndexByte(s, ','); commaIdx >= 0 {
+		count, err = strconv.Atoi(string(s[commaIdx+1:]))
+		if err != nil {
+			return 0, 0, err
+		}
+		s = s[:commaIdx]
+	}
+	start, err = strconv.Atoi(string(s))
+	return start, count, err
+}
+
+// parseHunkLine handles a single line of the hunk body.
+func (c *Change) parseHunkLine(ln []byte, hunk *hunkState) {
+	if len(ln) == 0 {
+		// Some tools strip the trailing whitespace of the empty context lines.
+		ln = []byte(" ")
+	}
+
+	switch ln[0] {
+	case ' ':
+		hunk.oldLeft--
+		hunk.newLeft--
+		hunk.newLine++
+	case '-':
+		hunk.oldLeft--
+	case '+':
+		c.addLine(hunk.newLine)
+		hunk.newLeft--
+		hunk.newLine++
+	}
+}
+
+// addLine adds the line to the AddedLines, merging it with the last range if possible.
+func (c *Change) addLine(line int) {
+	if n := len(c.AddedLines); n != 0 && c.AddedLines[n-1].To == line-1 {
+		r := &c.AddedLines[n-1]
+		r.To = line
+		r.Range = r.To - r.From
+		return
+	}
+	c.AddedLines = append(c.AddedLines, LineRange{From: line, To: line, HaveRange: true})
+}
+
 func execOutput(name string, args ...string) ([]byte, error) {
 	var buf bytes.Buffer
 	cmd := exec.Command(name, args...)
@@ -0,0 +1,207 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDiff(t *testing.T) {
+	added := func(from, to int) LineRange {
+		return LineRange{From: from, To: to, HaveRange: true, Range: to - from}
+	}
+
+	tests := []struct {
+		name string
+		diff string
+		want []Change
+	}{
+		{
+			name: "MultipleHunks",
+			diff: `diff --git a/a.php b/a.php
+--- a/a.php
++++ b/a.php
+@@ -1,3 +1,4 @@
+ <?php
++$x = 1;
+ f();
+ g();
+@@ -10,4 +11,5 @@ function h() {
+ $a = 1;
+-$b = 2;
++$b = 3;
++$c = 4;
+ $d = 5;
+--- comment
+`,
+			want: []Change{{
+				Type:          Changed,
+				OldName:       "a.php",
+				NewName:       "a.php",
+				Valid:         true,
+				LineRanges:    []LineRange{{From: 1, To: 4, HaveRange: true, Range: 3}, {From: 11, To: 15, HaveRange: true, Range: 4}},
+				OldLineRanges: []LineRange{{From: 1, To: 3, HaveRange: true, Range: 2}, {From: 10, To: 13, HaveRange: true, Range: 3}},
+				AddedLines:    []LineRange{added(2, 2), added(12, 13)},
+			}},
+		},
+
+		{
+			name: "RemovedLineLikeHeader",
+			diff: `--- a/a.php
++++ b/a.php
+@@ -1,3 +1,3 @@
+ <?php
+--- $x;
++++$x;
+ f();
+`,
+			want: []Change{{
+				Type:          Changed,
+				OldName:       "a.php",
+				NewName:       "a.php",
+				Valid:         true,
+				LineRanges:    []LineRange{{From: 1, To: 3, HaveRange: true, Range: 2}},
+				OldLineRanges: []LineRange{{From: 1, To: 3, HaveRange: true, Range: 2}},
+				AddedLines:    []LineRange{added(2, 2)},
+			}},
+		},
+
+		{
+			name: "NoNewlineAtEndOfFile",
+			diff: `--- a/a.php
++++ b/a.php
+@@ -1,2 +1,3 @@
+ <?php
+-f();
+\ No newline at end of file
++f();
++g();
+\ No newline at end of file
+`,
+			want: []Change{{
+				Type:          Changed,
+				OldName:       "a.php",
+				NewName:       "a.php",
+				Valid:         true,
+				LineRanges:    []LineRange{{From: 1, To: 3, HaveRange: true, Range: 2}},
+				OldLineRanges: []LineRange{{From: 1, To: 2, HaveRange: true, Range: 1}},
+				AddedLines:    []LineRange{added(2, 3)},
+			}},
+		},
+
+		{
+			name: "Timestamps",
+			diff: "--- old/a.php\t2020-07-13 20:58:30.000000000 +0300\n" +
+				"+++ new/a.php\t2020-07-13 20:59:00.000000000 +0300\n" +
+				"@@ -1 +1,2 @@\n" +
+				" <?php\n" +
+				"+f();\n",
+			want: []Change{{
+				Type:          Changed,
+				OldName:       "old/a.php",
+				NewName:       "new/a.php",
+				Valid:         true,
+				LineRanges:    []LineRange{{From: 1, To: 2, HaveRange: true, Range: 1}},
+				OldLineRanges: []LineRange{{From: 1, To: 1}},
+				AddedLines:    []LineRange{added(2, 2)},
+			}},
+		},
+
+		{
+			name: "AddedAndDeleted",
+			diff: `diff --git a/new.php b/new.php
+new file mode 100644
+--- /dev/null
++++ b/new.php
+@@ -0,0 +1,2 @@
++<?php
++f();
+diff --git a/old.php b/old.php
+deleted file mode 100644
+--- a/old.php
++++ /dev/null
+@@ -1,2 +0,0 @@
+-<?php
+-f();
+`,
+			want: []Change{
+				{
+					Type:          Added,
+					OldName:       "/dev/null",
+					NewName:       "new.php",
+					Valid:         true,
+					LineRanges:    []LineRange{{From: 1, To: 2, HaveRange: true, Range: 1}},
+					OldLineRanges: []LineRange{{From: 0, To: 0, HaveRange: true}},
+					AddedLines:    []LineRange{added(1, 2)},
+				},
+				{
+					Type:          Deleted,
+					OldName:       "old.php",
+					NewName:       "/dev/null",
+					Valid:         true,
+					LineRanges:    []LineRange{{From: 0, To: 0, HaveRange: true}},
+					OldLineRanges: []LineRange{{From: 1, To: 2, HaveRange: true, Range: 1}},
+				},
+			},
+		},
+
+		{
+			name: "Rename",
+			diff: `diff --git a/old.php b/new.php
+similarity index 90%
+rename from old.php
+rename to new.php
+--- a/old.php
++++ b/new.php
+@@ -1,2 +1,2 @@
+ <?php
+-f();
++g();
+`,
+			want: []Change{{
+				Type:          Changed,
+				OldName:       "old.php",
+				NewName:       "new.php",
+				Valid:         true,
+				LineRanges:    []LineRange{{From: 1, To: 2, HaveRange: true, Range: 1}},
+				OldLineRanges: []LineRange{{From: 1, To: 2, HaveRange: true, Range: 1}},
+				AddedLines:    []LineRange{added(2, 2)},
+			}},
+		},
+
+		{
+			name: "ZeroContext",
+			diff: `--- a/a.php
++++ b/a.php
+@@ -2 +2 @@ function f() {
+-  return 1;
++  return 2;
+@@ -5,0 +6,2 @@ function f() {
++g();
++h();
+`,
+			want: []Change{{
+				Type:          Changed,
+				OldName:       "a.php",
+				NewName:       "a.php",
+				Valid:         true,
+				LineRanges:    []LineRange{{From: 2, To: 2}, {From: 6, To: 7, HaveRange: true, Range: 1}},
+				OldLineRanges: []LineRange{{From: 2, To: 2}, {From: 5, To: 5, HaveRange: true}},
+				AddedLines:    []LineRange{added(2, 2), added(6, 7)},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			have, err := ParseDiff(strings.NewReader(test.diff))
+			if err != nil {
+				t.Fatalf("parse diff: %v", err)
+			}
+			if diff := cmp.Diff(test.want, have); diff != "" {
+				t.Errorf("unexpected changes (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
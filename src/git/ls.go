@@ -1,6 +1,7 @@
 package git
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -21,3 +22,41 @@ func UntrackedFiles(gitDir string) ([]string, error) {
 	filenames := strings.Split(strings.TrimSpace(string(out)), "\n")
 	return filenames, nil
 }
+
+// StagedBlobs returns the blob SHA1 of every staged file from the filenames
+// obtained with git ls-files command. Files that are not in the index are skipped.
+func StagedBlobs(gitDir string, filenames []string) (map[string]string, error) {
+	args := make([]string, 0, 5+len(filenames))
+	args = append(args, "--git-dir="+gitDir, "ls-files", "--stage", "-z", "--")
+	args = append(args, filenames...)
+
+	out, err := execOutput("git", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLsFilesStage(out)
+}
+
+// parseLsFilesStage parses the `git ls-files --stage -z` output.
+// Filenames are NUL-terminated, so they are never quoted.
+func parseLsFilesStage(out []byte) (map[string]string, error) {
+	// <mode> SP <sha1> SP <stage> TAB <filename> NUL
+	res := make(map[string]string)
+	for _, ln := range strings.Split(string(out), "\x00") {
+		if ln == "" {
+			continue
+		}
+		tabIdx := strings.IndexByte(ln, '\t')
+		if tabIdx < 0 {
+			return nil, fmt.Errorf("bad ls-files line: %s", ln)
+		}
+		fields := strings.Fields(ln[:tabIdx])
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("bad ls-files line: %s", ln)
+		}
+		res[ln[tabIdx+1:]] = fields[1]
+	}
+
+	return res, nil
+}
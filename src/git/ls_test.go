@@ -0,0 +1,137 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseLsFilesStage(t *testing.T) {
+	out := "100644 a94a8fe5ccb19ba61c4c0873d391e987982fbbd3 0\ta.php\x00" +
+		"100644 7c4a8d09ca3762af61e59520943dc26494f8941b 0\tdir/with space.php\x00" +
+		"100644 0000000000000000000000000000000000000001 0\t\"quoted\"\tname.php\x00"
+
+	have, err := parseLsFilesStage([]byte(out))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := map[string]string{
+		"a.php":                "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+		"dir/with space.php":   "7c4a8d09ca3762af61e59520943dc26494f8941b",
+		"\"quoted\"\tname.php": "0000000000000000000000000000000000000001",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected blobs (-want +have):\n%s", diff)
+	}
+
+	if _, err := parseLsFilesStage([]byte("100644 a94a8fe5\ta.php\x00")); err == nil {
+		t.Errorf("expected an error for a malformed line")
+	}
+}
+
+func TestStagedBlobs(t *testing.T) {
+	dir := initTestRepo(t)
+	writeTestFile(t, dir, "modified.php", "<?php\n")
+	writeTestFile(t, dir, "deleted.php", "<?php\n")
+	runTestGit(t, dir, "add", ".")
+	runTestGit(t, dir, "commit", "-q", "-m", "initial")
+
+	writeTestFile(t, dir, "modified.php", "<?php\nf();\n")
+	writeTestFile(t, dir, "dir with space/added file.php", "<?php\ng();\n")
+	writeTestFile(t, dir, "unstaged.php", "<?php\n")
+	runTestGit(t, dir, "add", "modified.php", "dir with space/added file.php")
+	runTestGit(t, dir, "rm", "-q", "deleted.php")
+
+	// The staged version must be returned even if the work tree differs.
+	writeTestFile(t, dir, "modified.php", "<?php\nunstaged();\n")
+
+	filenames := []string{"modified.php", "dir with space/added file.php", "deleted.php", "unstaged.php"}
+	blobs := func() map[string]string {
+		var res map[string]string
+		withWorkDir(t, dir, func() {
+			var err error
+			res, err = StagedBlobs(filepath.Join(dir, ".git"), filenames)
+			if err != nil {
+				t.Fatalf("staged blobs: %v", err)
+			}
+		})
+		return res
+	}()
+
+	want := map[string]string{
+		"modified.php":                  hashTestObject(t, dir, "<?php\nf();\n"),
+		"dir with space/added file.php": hashTestObject(t, dir, "<?php\ng();\n"),
+	}
+	if diff := cmp.Diff(want, blobs); diff != "" {
+		t.Errorf("unexpected blobs (-want +have):\n%s", diff)
+	}
+}
+
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	runTestGit(t, dir, "init", "-q")
+	runTestGit(t, dir, "config", "user.name", "test")
+	runTestGit(t, dir, "config", "user.email", "test@example.com")
+	return dir
+}
+
+func writeTestFile(t *testing.T, dir, filename, contents string) {
+	t.Helper()
+	filename = filepath.Join(dir, filepath.FromSlash(filename))
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func hashTestObject(t *testing.T, dir, contents string) string {
+	t.Helper()
+	cmd := exec.Command("git", "hash-object", "--stdin")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(contents)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git hash-object: %v", err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// withWorkDir runs f in dir, since the git commands
+// that get only the --git-dir treat the current directory as the work tree.
+func withWorkDir(t *testing.T, dir string, f func()) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	f()
+}
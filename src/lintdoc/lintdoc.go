@@ -26,13 +26,17 @@ func RenderMarkdownCheckDocumentation(w io.Writer, info linter.CheckerInfo) erro
 
 var templateShort = template.Must(template.New("short").Parse(`
 {{- .Name}} checker documentation
-
+{{if .Pack}}
+Enabled by the {{.Pack}} rule pack
+{{end}}
 {{.Comment -}}
 `))
 
 var templateFull = template.Must(template.New("short").Parse(`
 {{- .Name}} checker documentation{{if .Quickfix}} (auto fix available){{end}}
-
+{{if .Pack}}
+Enabled by the {{.Pack}} rule pack
+{{end}}
 {{.Comment}}
 
 Non-compliant code:
@@ -47,6 +51,8 @@ var templateMarkdownShort = template.Must(template.New("markdown-short").Parse(
 ### '{{.Name}}' checker
 {{if .Quickfix}}
 > Auto fix available
+{{end}}{{if .Pack}}
+> Enabled by the '{{.Pack}}' rule pack
 {{end}}
 #### Description
 
@@ -61,6 +67,8 @@ var templateMarkdownFull = template.Must(template.New("markdown").Parse(
 ### '{{.Name}}' checker
 {{if .Quickfix}}
 > Auto fix available
+{{end}}{{if .Pack}}
+> Enabled by the '{{.Pack}}' rule pack
 {{end}}
 #### Description
 
@@ -29,6 +29,16 @@ func TestRenderCheckDocumentation(t *testing.T) {
 
 Report nothing, but test short info rendering.`)
 
+	runTest(linter.CheckerInfo{
+		Name:    "packExample",
+		Comment: "Report nothing, but test rule pack rendering.",
+		Pack:    "security",
+	}, `packExample checker documentation
+
+Enabled by the security rule pack
+
+Report nothing, but test rule pack rendering.`)
+
 	runTest(linter.CheckerInfo{
 		Name:    "fullExample",
 		Comment: "Report nothing, but test full info rendering.",
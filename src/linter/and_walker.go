@@ -427,9 +427,9 @@ func (a *andWalker) handleConditionSafety(left ir.Node, right ir.Node, identical
 func (a *andWalker) runRules(w ir.Node) {
 	kind := ir.GetNodeKind(w)
 	if a.b.r.anyRset != nil {
-		a.b.r.runRules(w, a.b.ctx.sc, a.b.r.anyRset.RulesByKind[kind])
+		a.b.r.runRules(w, a.b.ctx.sc, a.b.path, a.b.r.anyRset.RulesByKind[kind])
 	} else if !a.b.rootLevel && a.b.r.localRset != nil {
-		a.b.r.runRules(w, a.b.ctx.sc, a.b.r.localRset.RulesByKind[kind])
+		a.b.r.runRules(w, a.b.ctx.sc, a.b.path, a.b.r.localRset.RulesByKind[kind])
 	}
 }
 
@@ -497,9 +497,12 @@ func (b *blockWalker) EnterNode(n ir.Node) (res bool) {
 		// Note: no need to check localRset for nil.
 		kind := ir.GetNodeKind(n)
 		if b.r.anyRset != nil {
-			b.r.runRules(n, b.ctx.sc, b.r.anyRset.RulesByKind[kind])
+			b.r.runRules(n, b.ctx.sc, b.path, b.r.anyRset.RulesByKind[kind])
 		} else if !b.rootLevel && b.r.localRset != nil {
-			b.r.runRules(n, b.ctx.sc, b.r.localRset.RulesByKind[kind])
+			b.r.runRules(n, b.ctx.sc, b.path, b.r.localRset.RulesByKind[kind])
+		}
+		if stmts, ok := nodeStmts(n); ok {
+			b.runSeqRules(stmts)
 		}
 	}
 
@@ -509,6 +512,18 @@ func (b *blockWalker) EnterNode(n ir.Node) (res bool) {
 	return res
 }
 
+// runSeqRules runs the statement sequence rules against stmts.
+//
+// Filters are evaluated in the scope that precedes the statements,
+// so the variables assigned inside the sequence are not typed yet.
+func (b *blockWalker) runSeqRules(stmts []ir.Node) {
+	if b.r.anyRset != nil {
+		b.r.runSeqRules(stmts, b.ctx.sc, b.path, b.r.anyRset.Sequences)
+	} else if !b.rootLevel && b.r.localRset != nil {
+		b.r.runSeqRules(stmts, b.ctx.sc, b.path, b.r.localRset.Sequences)
+	}
+}
+
 func (b *blockWalker) checkDupGlobal(s *ir.GlobalStmt) {
 	vars := make(map[string]struct{}, len(s.Vars))
 	for _, v := range s.Vars {
@@ -556,7 +556,12 @@ func (b *blockLinter) checkNew(e *ir.NewExpr) {
 		if ok {
 			b.report(e.Class, LevelError, "invalidNew", "Cannot instantiate trait %s", class.Name)
 		} else {
-			b.report(e.Class, LevelError, "undefinedClass", "Class or interface named %s does not exist", className)
+			b.walker.r.reportWithSuggestion(func() string {
+				return b.walker.r.suggestClass(className)
+			}, func() {
+				b.report(e.Class, LevelError, "undefinedClass", "Class or interface named %s does not exist", className)
+			})
+			b.walker.r.addImportQuickFix(e.Class, className)
 		}
 	} else {
 		if class.IsInterface() {
@@ -660,7 +665,11 @@ func (b *blockLinter) checkConstFetch(e *ir.ConstFetchExpr) {
 			b.report(e.Constant, LevelError, "constCase", "Constant '%s' should be used in lower case as '%s'", nm, expected)
 			b.addFixForBuiltInConstantCase(e.Constant, expected)
 		default:
-			b.report(e.Constant, LevelError, "undefinedConstant", "Undefined constant %s", nm)
+			b.walker.r.reportWithSuggestion(func() string {
+				return b.walker.r.suggestConstant(nm)
+			}, func() {
+				b.report(e.Constant, LevelError, "undefinedConstant", "Undefined constant %s", nm)
+			})
 		}
 	}
 }
@@ -1038,15 +1047,33 @@ func (b *blockLinter) checkDeprecatedFunctionCall(e *ir.FunctionCallExpr, call *
 
 	if call.info.WithDeprecationNote() {
 		b.report(e.Function, LevelNotice, "deprecated", "Call to deprecated function %s (%s)", utils.NameNodeToString(e.Function), call.info.DeprecationInfo)
+		b.addFixForDeprecatedCall(e, call.info.DeprecationInfo, nil, e.Args)
 		return
 	}
 
 	b.report(e.Function, LevelNotice, "deprecated", "Call to deprecated function %s", utils.NameNodeToString(e.Function))
 }
 
+// addFixForDeprecatedCall rewrites the deprecated call using
+// the replacement template from the deprecation info, if any.
+func (b *blockLinter) addFixForDeprecatedCall(call ir.Node, deprecation meta.DeprecationInfo, classNode ir.Node, args []ir.Node) {
+	if deprecation.Replacement == "" {
+		return
+	}
+	fix, ok := b.quickfix.DeprecatedReplacement(call, deprecation.Replacement, classNode, args)
+	if !ok {
+		return
+	}
+	b.walker.r.addQuickFix("deprecated", fix)
+}
+
 func (b *blockLinter) checkFunctionAvailability(e *ir.FunctionCallExpr, call *funcCallInfo) {
 	if !call.isFound && !b.walker.ctx.customFunctionExists(e.Function) {
-		b.report(e.Function, LevelError, "undefinedFunction", "Call to undefined function %s", utils.NameNodeToString(e.Function))
+		b.walker.r.reportWithSuggestion(func() string {
+			return b.walker.r.suggestFunction(call.funcName)
+		}, func() {
+			b.report(e.Function, LevelError, "undefinedFunction", "Call to undefined function %s", utils.NameNodeToString(e.Function))
+		})
 	}
 }
 
@@ -1349,7 +1376,11 @@ func (b *blockLinter) checkMethodCall(e *ir.MethodCallExpr) {
 		// The method is undefined, but we permit calling it if `method_exists`
 		// was called prior to that call.
 		if !b.walker.ctx.customMethodExists(e.Variable, call.methodName) && needShowUndefinedMethod {
-			b.report(e.Method, LevelError, "undefinedMethod", "Call to undefined method {%s}->%s()", call.methodCallerType, call.methodName)
+			b.walker.r.reportWithSuggestion(func() string {
+				return b.walker.r.suggestMethod(classNamesOf(call.methodCallerType), call.methodName)
+			}, func() {
+				b.report(e.Method, LevelError, "undefinedMethod", "Call to undefined method {%s}->%s()", call.methodCallerType, call.methodName)
+			})
 		}
 	} else if !call.isMagic && !parseState.IsTrait {
 		// Method is defined.
@@ -1392,6 +1423,7 @@ func (b *blockLinter) checkMethodCall(e *ir.MethodCallExpr) {
 		if deprecation.WithDeprecationNote() {
 			b.report(e.Method, LevelNotice, "deprecated", "Call to deprecated method {%s}->%s() (%s)",
 				call.methodCallerType, call.methodName, deprecation)
+			b.addFixForDeprecatedCall(e, deprecation, e.Variable, e.Args)
 		} else {
 			b.report(e.Method, LevelNotice, "deprecated", "Call to deprecated method {%s}->%s()",
 				call.methodCallerType, call.methodName)
@@ -1457,7 +1489,11 @@ func (b *blockLinter) checkStaticCall(e *ir.StaticCallExpr) {
 	}
 
 	if !call.isFound && !call.isMagic && !b.classParseState().IsTrait {
-		b.report(e.Call, LevelError, "undefinedMethod", "Call to undefined method %s::%s()", call.className, call.methodName)
+		b.walker.r.reportWithSuggestion(func() string {
+			return b.walker.r.suggestMethod([]string{call.className}, call.methodName)
+		}, func() {
+			b.report(e.Call, LevelError, "undefinedMethod", "Call to undefined method %s::%s()", call.className, call.methodName)
+		})
 	} else if !call.isParentCall && !call.methodInfo.Info.IsStatic() && !call.isMagic && !b.classParseState().IsTrait {
 		// Method is defined.
 		// parent::f() is permitted.
@@ -1470,6 +1506,7 @@ func (b *blockLinter) checkStaticCall(e *ir.StaticCallExpr) {
 		if deprecation.WithDeprecationNote() {
 			b.report(e.Call, LevelNotice, "deprecated", "Call to deprecated static method %s::%s() (%s)",
 				call.className, call.methodName, deprecation)
+			b.addFixForDeprecatedCall(e, deprecation, e.Class, e.Args)
 		} else {
 			b.report(e.Call, LevelNotice, "deprecated", "Call to deprecated static method %s::%s()",
 				call.className, call.methodName)
@@ -1523,7 +1560,11 @@ func (b *blockLinter) checkPropertyFetch(e *ir.PropertyFetchExpr) {
 		!globalMetaInfo.IsTrait &&
 		!b.walker.isThisInsideClosure(e.Variable) &&
 		needShowUndefinedProperty {
-		b.report(e.Property, LevelError, "undefinedProperty", "Property {%s}->%s does not exist", fetch.propertyFetchType, fetch.propertyNode.Value)
+		b.walker.r.reportWithSuggestion(func() string {
+			return b.walker.r.suggestProperty(classNamesOf(fetch.propertyFetchType), fetch.propertyNode.Value)
+		}, func() {
+			b.report(e.Property, LevelError, "undefinedProperty", "Property {%s}->%s does not exist", fetch.propertyFetchType, fetch.propertyNode.Value)
+		})
 	}
 
 	if fetch.isFound && !fetch.isMagic && !canAccess(globalMetaInfo, fetch.className, fetch.info.AccessLevel) {
@@ -1561,7 +1602,11 @@ func (b *blockLinter) checkStaticPropertyFetch(e *ir.StaticPropertyFetchExpr) {
 	b.checkClassSpecialNameCase(e, fetch.className)
 
 	if !fetch.isFound && !globalMetaInfo.IsTrait {
-		b.report(e.Property, LevelError, "undefinedProperty", "Property %s::$%s does not exist", fetch.className, fetch.propertyName)
+		b.walker.r.reportWithSuggestion(func() string {
+			return b.walker.r.suggestProperty([]string{fetch.className}, "$"+fetch.propertyName)
+		}, func() {
+			b.report(e.Property, LevelError, "undefinedProperty", "Property %s::$%s does not exist", fetch.className, fetch.propertyName)
+		})
 	}
 
 	if fetch.isFound && !canAccess(globalMetaInfo, fetch.info.ClassName, fetch.info.Info.AccessLevel) {
@@ -1585,7 +1630,11 @@ func (b *blockLinter) checkClassConstFetch(e *ir.ClassConstFetchExpr) {
 	}
 
 	if !fetch.isFound && !b.classParseState().IsTrait {
-		b.report(e.ConstantName, LevelError, "undefinedConstant", "Class constant %s::%s does not exist", fetch.className, fetch.constName)
+		b.walker.r.reportWithSuggestion(func() string {
+			return b.walker.r.suggestClassConstant([]string{fetch.className}, fetch.constName)
+		}, func() {
+			b.report(e.ConstantName, LevelError, "undefinedConstant", "Class constant %s::%s does not exist", fetch.className, fetch.constName)
+		})
 	}
 
 	if fetch.isFound && !canAccess(b.classParseState(), fetch.implClassName, fetch.info.AccessLevel) {
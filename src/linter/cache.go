@@ -54,7 +54,10 @@ import (
 //	55 - updated go version 1.16 -> 1.21
 //	56 - added isVariadic to meta.FuncInfo
 //	57 - added DeprecationInfo for property and const
-const cacheVersion = 57
+//	58 - added UsedMembers to meta.ClassInfo
+//	59 - added Hint and Default to meta.FuncParam, ReturnHint to meta.FuncInfo
+//	60 - Hint, Default and ReturnHint are set only for abstract methods
+const cacheVersion = 60
 
 var (
 	errWrongVersion = errors.New("Wrong cache version")
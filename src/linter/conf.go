@@ -12,17 +12,25 @@ import (
 
 	"github.com/VKCOM/noverify/src/baseline"
 	"github.com/VKCOM/noverify/src/inputs"
+	"github.com/VKCOM/noverify/src/quickfix"
 	"github.com/VKCOM/noverify/src/rules"
 )
 
 type Config struct {
 	// BaselineProfile is a suppression database for warnings.
 	// Nil profile is an empty suppression profile.
-	BaselineProfile       *baseline.Profile
+	BaselineProfile *baseline.Profile
+	// BaselineRoot is a directory relative to which
+	// the BaselineProfile file paths are resolved.
+	BaselineRoot          string
 	ComputeBaselineHashes bool // Whether we need to compute report hashes
 	ConservativeBaseline  bool
 
 	ApplyQuickFixes bool
+	// QuickFixHandler is called with the file quick fixes instead of
+	// quickfix.Apply if it's set, so the fixes can be previewed or
+	// applied in a different way. It's called concurrently.
+	QuickFixHandler func(filename string, contents []byte, fixes []quickfix.TextEdit) error
 
 	// KPHP tells whether we're working in KPHP-compatible mode.
 	KPHP bool
@@ -40,6 +48,10 @@ type Config struct {
 	// Rules is a set of dynamically loaded linter diagnostics.
 	Rules *rules.Set
 
+	// CollectRuleCaptures tells whether the dynamic rule reports
+	// should contain the source text of the named pattern captures.
+	CollectRuleCaptures bool
+
 	// PathRules is a set of specific rules for paths.
 	PathRules *RuleNode
 
@@ -73,6 +85,14 @@ type Config struct {
 	PhpVersion *version.Version
 
 	StrictMixed bool
+
+	// UnusedParams enables the unusedParam analysis.
+	// UnusedPrivateMembers enables the unusedPrivateMember analysis.
+	//
+	// Both are disabled unless requested explicitly, since these
+	// checks are too noisy for most of the existing code.
+	UnusedParams         bool
+	UnusedPrivateMembers bool
 }
 
 type RuleNode struct {
@@ -75,6 +75,10 @@ type CheckerInfo struct {
 	// Extends tells the check is created by a dynamic rule that
 	// extends the internal linter rule.
 	Extends bool
+
+	// Pack is a name of the optional rule pack this check comes from.
+	// Empty for the checks that are always available.
+	Pack string
 }
 
 // BlockChecker is a custom linter that is called on block level
@@ -335,6 +339,7 @@ func (reg *CheckersRegistry) DeclareRules(rset *rules.Set) {
 			Before:   doc.Before,
 			After:    doc.After,
 			Extends:  doc.Extends,
+			Pack:     rset.Pack,
 		})
 	}
 }
@@ -0,0 +1,220 @@
+package linter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irfmt"
+	"github.com/VKCOM/noverify/src/ir/irutil"
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/solver"
+	"github.com/VKCOM/noverify/src/types"
+)
+
+// addStubHints sets the type hints and default values of the params
+// that are used by the method stubs quickfix, see methodStub.
+// It returns the return type hint.
+//
+// Only abstract and interface methods can be stubbed, so the hints
+// of other methods and functions are not computed at all.
+func (d *rootWalker) addStubHints(meth *ir.ClassMethodStmt, params []meta.FuncParam) (returnHint string) {
+	if len(params) == len(meth.Params) {
+		for i, p := range meth.Params {
+			param, ok := p.(*ir.Parameter)
+			if !ok {
+				continue
+			}
+			params[i].Hint = d.qualifiedTypeHint(param.VariableType)
+			params[i].Default = d.qualifiedExpr(param.DefaultValue)
+		}
+	}
+	return d.qualifiedTypeHint(meth.ReturnType)
+}
+
+// qualifiedTypeHint returns the type hint source code with all
+// class names resolved, so it can be used outside of the current file.
+func (d *rootWalker) qualifiedTypeHint(n ir.Node) string {
+	if n == nil {
+		return ""
+	}
+	n = irutil.NodeClone(n)
+	d.qualifyTypeHint(n)
+	return irfmt.Node(n)
+}
+
+func (d *rootWalker) qualifyTypeHint(n ir.Node) {
+	switch n := n.(type) {
+	case *ir.Nullable:
+		d.qualifyTypeHint(n.Expr)
+	case *ir.Union:
+		for _, typ := range n.Types {
+			d.qualifyTypeHint(typ)
+		}
+	case *ir.Name:
+		lower := strings.ToLower(n.Value)
+		if types.IsTrivial(lower) || lower == "array" || lower == "static" {
+			return
+		}
+		d.qualifyClassName(n)
+	}
+}
+
+// qualifiedExpr is like qualifiedTypeHint, but for constant expressions,
+// like the param default values.
+func (d *rootWalker) qualifiedExpr(n ir.Node) string {
+	if n == nil {
+		return ""
+	}
+	n = irutil.NodeClone(n)
+	irutil.Inspect(n, func(n ir.Node) bool {
+		switch n := n.(type) {
+		case *ir.ClassConstFetchExpr:
+			if nm, ok := n.Class.(*ir.Name); ok {
+				d.qualifyClassName(nm)
+			}
+		case *ir.NewExpr:
+			if nm, ok := n.Class.(*ir.Name); ok {
+				d.qualifyClassName(nm)
+			}
+		}
+		return true
+	})
+	return irfmt.Node(n)
+}
+
+func (d *rootWalker) qualifyClassName(n *ir.Name) {
+	if strings.EqualFold(n.Value, "static") {
+		return
+	}
+	className, ok := solver.GetClassName(d.ctx.st, n)
+	if ok {
+		n.Value = className
+	}
+}
+
+// addMethodStubsQuickFix inserts the stubs for the unimplemented methods
+// before the closing brace of the classNode.
+func (r *rootChecker) addMethodStubsQuickFix(classNode ir.Node, methods []meta.FuncInfo) {
+	if len(methods) == 0 {
+		return
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
+	stubs := make([]*ir.ClassMethodStmt, 0, len(methods))
+	docs := make([]string, 0, len(methods))
+	for _, method := range methods {
+		key := strings.ToLower(r.state.CurrentClass + "::" + method.Name)
+		if _, ok := r.walker.ctx.stubbedMethods[key]; ok {
+			// Already generated for another interface.
+			continue
+		}
+		if r.walker.ctx.stubbedMethods == nil {
+			r.walker.ctx.stubbedMethods = make(map[string]struct{})
+		}
+		r.walker.ctx.stubbedMethods[key] = struct{}{}
+
+		stubs = append(stubs, methodStub(method))
+		docs = append(docs, r.methodStubDoc(method))
+	}
+
+	fix, ok := r.quickfix.MethodStubs(classNode, stubs, docs)
+	if ok {
+		r.walker.addQuickFix("unimplemented", fix)
+	}
+}
+
+// methodStub creates a method declaration with the same signature
+// as the abstract method and a body that throws an exception.
+func methodStub(method meta.FuncInfo) *ir.ClassMethodStmt {
+	modifiers := []*ir.Identifier{{Value: method.AccessLevel.String()}}
+	if method.IsStatic() {
+		modifiers = append(modifiers, &ir.Identifier{Value: "static"})
+	}
+
+	params := make([]ir.Node, 0, len(method.Params))
+	for i, p := range method.Params {
+		param := &ir.Parameter{
+			ByRef:    p.IsRef,
+			Variadic: method.Flags&meta.FuncVariadic != 0 && i == len(method.Params)-1,
+			Variable: &ir.SimpleVar{Name: p.Name},
+		}
+		// The param source code is already formatted, so we
+		// use names to print it as is.
+		if p.Hint != "" {
+			param.VariableType = &ir.Name{Value: p.Hint}
+		}
+		if p.Default != "" {
+			param.DefaultValue = &ir.Name{Value: p.Default}
+		}
+		params = append(params, param)
+	}
+
+	var returnType ir.Node
+	if method.ReturnHint != "" {
+		returnType = &ir.Name{Value: method.ReturnHint}
+	}
+
+	return &ir.ClassMethodStmt{
+		Modifiers:  modifiers,
+		MethodName: &ir.Identifier{Value: method.Name},
+		Params:     params,
+		ReturnType: returnType,
+		Stmt: &ir.StmtList{
+			Stmts: []ir.Node{
+				&ir.ThrowStmt{
+					Expr: &ir.NewExpr{
+						Class: &ir.Name{Value: `\BadMethodCallException`},
+						Args: []ir.Node{
+							&ir.Argument{Expr: &ir.String{Value: "Not implemented"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// methodStubDoc returns the phpdoc comment with the param and return types
+// of the method, or an empty string if types are unknown.
+func (r *rootChecker) methodStubDoc(method meta.FuncInfo) string {
+	var lines []string
+	for i, p := range method.Params {
+		typ := r.phpdocType(method.Params[i].Typ)
+		if typ == "" {
+			continue
+		}
+		name := "$" + p.Name
+		if method.Flags&meta.FuncVariadic != 0 && i == len(method.Params)-1 {
+			typ = strings.TrimSuffix(typ, "[]")
+			name = "..." + name
+		}
+		lines = append(lines, " * @param "+typ+" "+name)
+	}
+	if typ := r.phpdocType(method.Typ); typ != "" && typ != "void" {
+		lines = append(lines, " * @return "+typ)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "/**\n" + strings.Join(lines, "\n") + "\n */"
+}
+
+func (r *rootChecker) phpdocType(typ types.Map) string {
+	if typ.Empty() {
+		return ""
+	}
+	resolved := solver.ResolveTypes(r.info, r.state.CurrentClass, typ, make(solver.ResolverMap))
+	list := make([]string, 0, len(resolved))
+	for t := range resolved {
+		if types.IsShape(t) || types.IsAnonClass(t) || strings.Contains(t, "$") {
+			// Auto-generated types can't be written in phpdoc.
+			return ""
+		}
+		list = append(list, t)
+	}
+	sort.Strings(list)
+	return strings.Join(list, "|")
+}
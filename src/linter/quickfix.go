@@ -3,11 +3,16 @@ package linter
 import (
 	"bytes"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irfmt"
 	"github.com/VKCOM/noverify/src/quickfix"
 	"github.com/VKCOM/noverify/src/workspace"
 	"github.com/VKCOM/php-parser/pkg/position"
+	"github.com/VKCOM/php-parser/pkg/token"
 )
 
 type QuickFixGenerator struct {
@@ -97,3 +102,145 @@ func (g *QuickFixGenerator) GetType(node ir.Node, isFunctionName, nodeText strin
 		Replacement: isFunctionName,
 	}
 }
+
+// ImportClass inserts the use statement for the className after the pos.
+func (g *QuickFixGenerator) ImportClass(pos int, prefix, className string) quickfix.TextEdit {
+	return quickfix.TextEdit{
+		StartPos:    pos,
+		EndPos:      pos,
+		Replacement: prefix + "use " + strings.TrimPrefix(className, `\`) + ";",
+	}
+}
+
+// MethodStubs inserts the method declarations before the closing brace of the class.
+// Every method is preceded by the corresponding doc comment, if it's not empty.
+func (g *QuickFixGenerator) MethodStubs(classNode ir.Node, methods []*ir.ClassMethodStmt, docs []string) (quickfix.TextEdit, bool) {
+	var class ir.Class
+	var closeBracket *token.Token
+	switch n := classNode.(type) {
+	case *ir.ClassStmt:
+		class, closeBracket = n.Class, n.CloseCurlyBracketTkn
+	case *ir.AnonClassExpr:
+		class, closeBracket = n.Class, n.CloseCurlyBracketTkn
+	}
+	if closeBracket == nil || len(methods) == 0 {
+		return quickfix.TextEdit{}, false
+	}
+
+	// Use the indentation of the existing class members, if any.
+	indent := "    "
+	if len(class.Stmts) != 0 {
+		indent = g.lineIndent(ir.GetPosition(class.Stmts[0]).StartPos)
+	}
+
+	var buf strings.Builder
+	for i, method := range methods {
+		var methodBuf bytes.Buffer
+		irfmt.NewPrettyPrinter(&methodBuf, indent).Print(method)
+
+		buf.WriteString("\n")
+		text := methodBuf.String()
+		if docs[i] != "" {
+			text = docs[i] + "\n" + text
+		}
+		for _, line := range strings.Split(text, "\n") {
+			buf.WriteString(indent + line + "\n")
+		}
+	}
+
+	// Insert stubs at the beginning of the line with closing brace,
+	// unless there is some code before it, like in `class A {}`.
+	pos := closeBracket.Position.StartPos
+	if indent := g.lineIndent(pos); len(indent) == pos-g.lineStart(pos) {
+		pos -= len(indent)
+	}
+	return quickfix.TextEdit{
+		StartPos:    pos,
+		EndPos:      pos,
+		Replacement: buf.String(),
+	}, true
+}
+
+func (g *QuickFixGenerator) lineStart(pos int) int {
+	return bytes.LastIndexByte(g.file.Contents()[:pos], '\n') + 1
+}
+
+// lineIndent returns the whitespace prefix of the line with pos.
+func (g *QuickFixGenerator) lineIndent(pos int) string {
+	contents := g.file.Contents()
+	lineStart := g.lineStart(pos)
+	lineEnd := lineStart
+	for lineEnd < pos && (contents[lineEnd] == ' ' || contents[lineEnd] == '\t') {
+		lineEnd++
+	}
+	return string(contents[lineStart:lineEnd])
+}
+
+var deprecationPlaceholderRegexp = regexp.MustCompile(`%(parametersList|parameter(\d+)|class)%`)
+
+// DeprecatedReplacement renders the deprecation replacement template
+// in the way it's done for the JetBrains #[Deprecated] attribute:
+//
+//	%parametersList% - all call arguments
+//	%parameter0%     - the first call argument (and so on)
+//	%class%          - the object or class expression the method is called on
+//
+// The classNode is nil for function calls.
+//
+// It returns false if the template can't be applied to the given call.
+func (g *QuickFixGenerator) DeprecatedReplacement(call ir.Node, template string, classNode ir.Node, args []ir.Node) (quickfix.TextEdit, bool) {
+	if !deprecationPlaceholderRegexp.MatchString(template) {
+		// Without placeholders the replacement is only a hint
+		// like "@see g()", not an expression template.
+		return quickfix.TextEdit{}, false
+	}
+
+	argTexts := make([]string, 0, len(args))
+	argExprTexts := make([]string, 0, len(args))
+	for _, arg := range args {
+		arg, ok := arg.(*ir.Argument)
+		if !ok || arg.Name != nil {
+			// Named arguments can't be mapped to positions.
+			return quickfix.TextEdit{}, false
+		}
+		argTexts = append(argTexts, g.nodeText(arg))
+		argExprTexts = append(argExprTexts, g.nodeText(arg.Expr))
+	}
+
+	canApply := true
+	replacement := deprecationPlaceholderRegexp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		m := deprecationPlaceholderRegexp.FindStringSubmatch(placeholder)
+		switch {
+		case m[1] == "parametersList":
+			return strings.Join(argTexts, ", ")
+		case m[1] == "class":
+			if classNode == nil {
+				canApply = false
+				return ""
+			}
+			return g.nodeText(classNode)
+		default:
+			i, err := strconv.Atoi(m[2])
+			if err != nil || i >= len(argExprTexts) {
+				canApply = false
+				return ""
+			}
+			return argExprTexts[i]
+		}
+	})
+	if !canApply {
+		return quickfix.TextEdit{}, false
+	}
+
+	pos := ir.GetPosition(call)
+	return quickfix.TextEdit{
+		StartPos:    pos.StartPos,
+		EndPos:      pos.EndPos,
+		Replacement: replacement,
+	}, true
+}
+
+func (g *QuickFixGenerator) nodeText(n ir.Node) string {
+	pos := ir.GetPosition(n)
+	return string(g.file.Contents()[pos.StartPos:pos.EndPos])
+}
@@ -520,7 +520,7 @@ public function process($acts, $config) {
 		{
 			Name:     "unimplemented",
 			Default:  true,
-			Quickfix: false,
+			Quickfix: true,
 			Comment:  `Report classes that don't implement their contract.`,
 			Before: `class MyObj implements Serializable {
   public function serialize() { /* ... */ }
@@ -544,7 +544,7 @@ public function process($acts, $config) {
 		{
 			Name:     "undefinedClass",
 			Default:  true,
-			Quickfix: false,
+			Quickfix: true,
 			Comment:  `Report usages of undefined class or interface.`,
 			Before:   `$foo = new UndefinedClass;`,
 			After:    `$foo = new DefinedClass;`,
@@ -652,6 +652,34 @@ return [$err];`,
 return [$result, $err];`,
 		},
 
+		{
+			Name:     "unusedParam",
+			Default:  false,
+			Quickfix: false,
+			Comment:  `Report function and method params that are never read.`,
+			Before: `function greet($name, $age) { // $age is unused.
+  return "Hello, $name";
+}`,
+			After: `function greet($name) {
+  return "Hello, $name";
+}`,
+		},
+
+		{
+			Name:     "unusedPrivateMember",
+			Default:  false,
+			Quickfix: false,
+			Comment:  `Report private methods, properties and constants that are never used inside their class.`,
+			Before: `class Foo {
+  private $cache = []; // $cache is never used.
+
+  public function f() { return 1; }
+}`,
+			After: `class Foo {
+  public function f() { return 1; }
+}`,
+		},
+
 		{
 			Name:     "redundantCast",
 			Default:  false,
@@ -730,7 +758,7 @@ case INC:
 		{
 			Name:     "deprecated",
 			Default:  true,
-			Quickfix: false,
+			Quickfix: true,
 			Comment:  `Report usages of deprecated symbols.`,
 			Before: `/**
  * @deprecated Use g() instead
@@ -1380,6 +1408,20 @@ type Report struct {
 	StartChar int    `json:"start_char"`
 	EndChar   int    `json:"end_char"`
 	Hash      uint64 `json:"hash"`
+
+	// Author, Commit and Date describe the last change of the report line.
+	// They're only filled by the --annotate-blame mode.
+	Author string `json:"author,omitempty"`
+	Commit string `json:"commit,omitempty"`
+	Date   string `json:"date,omitempty"`
+
+	// Owners are the report file owners from the CODEOWNERS file.
+	// They're only filled if --codeowners, --owner or --group-by=owner is given.
+	Owners []string `json:"owners,omitempty"`
+
+	// Captures map the named dynamic rule pattern captures to their source text.
+	// They're only filled if Config.CollectRuleCaptures is set.
+	Captures map[string]string `json:"captures,omitempty"`
 }
 
 var severityNames = map[int]string{
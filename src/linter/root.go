@@ -64,6 +64,10 @@ type rootWalker struct {
 	// inside common classes and anonymous.
 	currentClassNodeStack irutil.NodePath
 
+	// path is a stack of the nodes that are being visited.
+	// Used by the rules context constraints.
+	path irutil.NodePath
+
 	allowDisabledRegexp *regexp.Regexp // user-defined flag that files suitable for this regular expression should not be linted
 	linterDisabled      bool           // flag indicating whether linter is disabled. Flag is set to true only if the file
 	// name matches the pattern and @linter disable was encountered
@@ -74,6 +78,10 @@ type rootWalker struct {
 
 	reports []*Report
 
+	// suggest returns the "did you mean" suggestion for
+	// the report that is being emitted, see reportWithSuggestion.
+	suggest func() string
+
 	config *Config
 
 	checkersFilter *CheckersFilter
@@ -131,6 +139,7 @@ func (d *rootWalker) EnterNode(n ir.Node) (res bool) {
 	n.IterateTokens(d.handleCommentToken)
 
 	state.EnterNode(d.ctx.st, n)
+	d.path.Push(n)
 
 	switch n := n.(type) {
 	case *ir.DeclareStmt:
@@ -146,6 +155,10 @@ func (d *rootWalker) EnterNode(n ir.Node) (res bool) {
 				}
 			}
 		}
+		if d.ctx.importPos == 0 {
+			d.ctx.importPos = n.Position.EndPos
+			d.ctx.importPrefix = "\n\n"
+		}
 
 	case *ir.AnonClassExpr:
 		d.currentClassNodeStack.Push(n)
@@ -210,9 +223,23 @@ func (d *rootWalker) EnterNode(n ir.Node) (res bool) {
 
 		d.meta.Classes.Set(d.ctx.st.CurrentClass, cl)
 
+		d.checker.CheckUnusedPrivateMembers(n)
+
 	case *ir.TraitStmt:
 		d.currentClassNodeStack.Push(n)
 		d.checker.CheckKeywordCase(n, "trait")
+
+		if !d.metaInfo().IsIndexingComplete() {
+			refs := make(memberRefs)
+			for _, stmt := range n.Stmts {
+				collectMemberRefs(refs, stmt)
+			}
+			if len(refs) != 0 {
+				cl := d.getClass()
+				cl.UsedMembers = refs
+				d.meta.Traits.Set(d.ctx.st.CurrentClass, cl)
+			}
+		}
 		d.checker.CheckCommentMisspellings(n.TraitName, n.Doc.Raw)
 		d.checker.CheckIdentMisspellings(n.TraitName)
 	case *ir.TraitUseStmt:
@@ -257,6 +284,19 @@ func (d *rootWalker) EnterNode(n ir.Node) (res bool) {
 
 	case *ir.NamespaceStmt:
 		d.checker.CheckKeywordCase(n, "namespace")
+		if n.OpenCurlyBracketTkn != nil {
+			d.ctx.importPos = n.OpenCurlyBracketTkn.Position.EndPos
+		} else {
+			d.ctx.importPos = n.Position.EndPos
+		}
+		d.ctx.importPrefix = "\n\n"
+
+	case *ir.UseListStmt:
+		d.ctx.importPos = n.Position.EndPos
+		d.ctx.importPrefix = "\n"
+	case *ir.GroupUseStmt:
+		d.ctx.importPos = n.Position.EndPos
+		d.ctx.importPrefix = "\n"
 	}
 
 	for _, c := range d.custom {
@@ -265,7 +305,10 @@ func (d *rootWalker) EnterNode(n ir.Node) (res bool) {
 
 	if d.metaInfo().IsIndexingComplete() && d.rootRset != nil {
 		kind := ir.GetNodeKind(n)
-		d.runRules(n, d.scope(), d.rootRset.RulesByKind[kind])
+		d.runRules(n, d.scope(), d.path, d.rootRset.RulesByKind[kind])
+		if stmts, ok := nodeStmts(n); ok {
+			d.runSeqRules(stmts, d.scope(), d.path, d.rootRset.Sequences)
+		}
 	}
 
 	if !res {
@@ -274,6 +317,7 @@ func (d *rootWalker) EnterNode(n ir.Node) (res bool) {
 		// But we still need to "leave" them if they
 		// were entered in the ClassParseState.
 		state.LeaveNode(d.ctx.st, n)
+		d.path.Pop()
 	}
 	return res
 }
@@ -292,6 +336,7 @@ func (d *rootWalker) LeaveNode(n ir.Node) {
 	}
 
 	state.LeaveNode(d.ctx.st, n)
+	d.path.Pop()
 
 	for _, c := range d.custom {
 		c.AfterLeaveNode(n)
@@ -381,6 +426,10 @@ type handleFuncResult struct {
 	returnTypes            types.Map
 	prematureExitFlags     int
 	callsParentConstructor bool
+
+	// unusedParams contains names of the params that were never read.
+	// It's nil if the function calls func_get_args().
+	unusedParams map[string]struct{}
 }
 
 func (d *rootWalker) handleArrowFuncExpr(params []meta.FuncParam, expr ir.Node, sc *meta.Scope, parentBlockWalker *blockWalker) handleFuncResult {
@@ -455,6 +504,9 @@ func (d *rootWalker) handleFuncStmts(params []meta.FuncParam, uses, stmts []ir.N
 			b.unusedParams[p.Name] = struct{}{}
 		}
 	}
+	if d.metaInfo().IsIndexingComplete() {
+		b.runSeqRules(stmts)
+	}
 	for _, s := range stmts {
 		b.addStatement(s)
 		s.Walk(b)
@@ -478,10 +530,16 @@ func (d *rootWalker) handleFuncStmts(params []meta.FuncParam, uses, stmts []ir.N
 		b.returnTypes = types.MixedType
 	}
 
+	var unusedParams map[string]struct{}
+	if !b.callsFuncGetArgs {
+		unusedParams = b.unusedParams
+	}
+
 	return handleFuncResult{
 		returnTypes:            b.returnTypes,
 		prematureExitFlags:     prematureExitFlags,
 		callsParentConstructor: b.callsParentConstructor,
+		unusedParams:           unusedParams,
 	}
 }
 
@@ -1173,6 +1231,9 @@ func (d *rootWalker) enterClassMethod(meth *ir.ClassMethodStmt) bool {
 	if nm == `__construct` {
 		d.checker.CheckParentConstructorCall(meth.MethodName, funcInfo.callsParentConstructor)
 	}
+	if _, ok := meth.Stmt.(*ir.StmtList); ok {
+		d.checker.CheckMethodUnusedParams(meth, funcInfo.unusedParams)
+	}
 
 	returnTypes := functionReturnType(doc.ReturnType, returnTypeHint, actualReturnTypes)
 
@@ -1194,6 +1255,11 @@ func (d *rootWalker) enterClassMethod(meth *ir.ClassMethodStmt) bool {
 		funcFlags |= meta.FuncVariadic
 	}
 
+	var returnHint string
+	if insideInterface || modif.abstract {
+		returnHint = d.addStubHints(meth, funcParams.params)
+	}
+
 	class.Methods.Set(nm, meta.FuncInfo{
 		Params:          funcParams.params,
 		Name:            nm,
@@ -1205,6 +1271,7 @@ func (d *rootWalker) enterClassMethod(meth *ir.ClassMethodStmt) bool {
 		ExitFlags:       exitFlags,
 		DeprecationInfo: doc.Deprecation,
 		Internal:        doc.Internal,
+		ReturnHint:      returnHint,
 	})
 
 	if nm == "getIterator" && d.metaInfo().IsIndexingComplete() && solver.Implements(d.metaInfo(), d.ctx.st.CurrentClass, `\IteratorAggregate`) {
@@ -1376,6 +1443,46 @@ func (d *rootWalker) addQuickFix(checkName string, fix quickfix.TextEdit) {
 	d.ctx.fixes = append(d.ctx.fixes, fix)
 }
 
+// addImportQuickFix adds a use statement for the undefined className
+// if n is an unqualified class name and there is exactly one
+// class with the same name in another namespace.
+func (d *rootWalker) addImportQuickFix(n ir.Node, className string) {
+	if !d.config.ApplyQuickFixes || !d.checkersFilter.IsEnabledReport("undefinedClass", d.ctx.st.CurrentFile) {
+		return
+	}
+	nm, ok := n.(*ir.Name)
+	if !ok || nm.IsFullyQualified() || nm.NumParts() != 1 {
+		return
+	}
+	if _, ok := d.ctx.st.Uses[nm.Value]; ok {
+		// The name is already used by another import.
+		return
+	}
+	importName := d.importableClass(className)
+	if importName == "" {
+		return
+	}
+	if d.ctx.importPos == 0 {
+		// There is no namespace, declare or use statement,
+		// so the use statement goes right after the open tag.
+		openTag := bytes.Index(d.file.Contents(), []byte("<?php"))
+		if openTag == -1 {
+			return
+		}
+		d.ctx.importPos = openTag + len("<?php")
+		d.ctx.importPrefix = "\n\n"
+	}
+	if _, ok := d.ctx.imports[importName]; ok {
+		return
+	}
+	if d.ctx.imports == nil {
+		d.ctx.imports = make(map[string]struct{})
+	}
+	d.ctx.imports[importName] = struct{}{}
+
+	d.addQuickFix("undefinedClass", d.checker.quickfix.ImportClass(d.ctx.importPos, d.ctx.importPrefix, importName))
+}
+
 func (d *rootWalker) currentFunction() (meta.FuncInfo, bool) {
 	name := d.ctx.st.CurrentFunction
 	if name == "" {
@@ -1758,6 +1865,11 @@ func (d *rootWalker) ReportLocation(loc ir.Location, level int, checkName, msg s
 		return
 	}
 
+	message := fmt.Sprintf(msg, args...)
+	if d.suggest != nil {
+		message += didYouMean(d.suggest())
+	}
+
 	d.reports = append(d.reports, &Report{
 		CheckName: checkName,
 		Context:   string(contextLine),
@@ -1766,11 +1878,22 @@ func (d *rootWalker) ReportLocation(loc ir.Location, level int, checkName, msg s
 		Line:      loc.StartLine + 1,
 		Level:     level,
 		Filename:  strings.ReplaceAll(d.ctx.st.CurrentFile, "\\", "/"), // To make output stable between platforms, see #572
-		Message:   fmt.Sprintf(msg, args...),
+		Message:   message,
 		Hash:      hash,
 	})
 }
 
+// reportWithSuggestion calls report and appends the "did you mean"
+// suggestion returned by suggest to the emitted report message.
+//
+// The suggestion searches through all known symbols, so it's computed
+// only if the report is emitted. It's not a part of the baseline hash.
+func (d *rootWalker) reportWithSuggestion(suggest func() string, report func()) {
+	d.suggest = suggest
+	report()
+	d.suggest = nil
+}
+
 // reportHash computes the ReportLocation signature hash for the baseline.
 func (d *rootWalker) reportHash(loc *ir.Location, contextLine []byte, checkName, msg string) uint64 {
 	// Since we store class::method scope, renaming a class would cause baseline
@@ -1865,10 +1988,10 @@ func (d *rootWalker) reportUndefinedVariable(v ir.Node, maybeHave bool, path iru
 
 // Rules part.
 
-func (d *rootWalker) runRules(n ir.Node, sc *meta.Scope, rlist []rules.Rule) {
+func (d *rootWalker) runRules(n ir.Node, sc *meta.Scope, path irutil.NodePath, rlist []rules.Rule) {
 	for i := range rlist {
 		rule := &rlist[i]
-		if d.runRule(n, sc, rule) {
+		if d.runRule(n, sc, path, rule) {
 			// Stop at the first matched rule per IR node.
 			// Sometimes it's useful to report more, but we rely on the rules definition
 			// order so we can report more specific issues instead of the
@@ -1923,11 +2046,40 @@ func (d *rootWalker) isSuppressed(n ir.Node, checkName string) bool {
 	return false
 }
 
-func (d *rootWalker) runRule(n ir.Node, sc *meta.Scope, rule *rules.Rule) bool {
+// runSeqRules runs the statement sequence rules against every
+// sub-sequence of stmts. Like with runRules, at most one rule
+// is reported per sequence start statement.
+func (d *rootWalker) runSeqRules(stmts []ir.Node, sc *meta.Scope, path irutil.NodePath, rlist []rules.Rule) {
+	if len(rlist) == 0 {
+		return
+	}
+	for i := range stmts {
+		for j := range rlist {
+			rule := &rlist[j]
+			m, ok := rule.Matcher.MatchSeq(stmts[i:])
+			if ok && d.handleRuleMatch(m, sc, path, rule) {
+				break
+			}
+		}
+	}
+}
+
+func (d *rootWalker) runRule(n ir.Node, sc *meta.Scope, path irutil.NodePath, rule *rules.Rule) bool {
 	m, ok := rule.Matcher.Match(n)
 	if !ok {
 		return false
 	}
+	return d.handleRuleMatch(m, sc, path, rule)
+}
+
+// handleRuleMatch reports the matched rule if its filters are satisfied.
+// It returns false if the match was rejected.
+func (d *rootWalker) handleRuleMatch(m phpgrep.MatchData, sc *meta.Scope, path irutil.NodePath, rule *rules.Rule) bool {
+	n := m.Node
+
+	if !d.checkRuleContext(n, path, rule) {
+		return false
+	}
 
 	if d.isSuppressed(n, rule.Name) {
 		return false
@@ -1973,8 +2125,17 @@ func (d *rootWalker) runRule(n ir.Node, sc *meta.Scope, rule *rules.Rule) bool {
 		message += " | More about this rule: " + rule.Link
 	}
 
+	numReports := len(d.reports)
 	d.Report(location, rule.Level, rule.Name, "%s", message)
 
+	if d.config.CollectRuleCaptures && len(d.reports) > numReports && len(m.Capture) != 0 {
+		captures := make(map[string]string, len(m.Capture))
+		for _, c := range m.Capture {
+			captures[c.Name] = d.nodeText(c.Node)
+		}
+		d.reports[len(d.reports)-1].Captures = captures
+	}
+
 	if d.config.ApplyQuickFixes && rule.Fix != "" {
 		// As rule sets contain only enabled rules,
 		// we should be OK without any filtering here.
@@ -1989,6 +2150,35 @@ func (d *rootWalker) runRule(n ir.Node, sc *meta.Scope, rule *rules.Rule) bool {
 	return true
 }
 
+// checkRuleContext reports whether the rule context constraints
+// are satisfied for the n node located at path.
+func (d *rootWalker) checkRuleContext(n ir.Node, path irutil.NodePath, rule *rules.Rule) bool {
+	if rule.PhpVersion != nil && !rule.PhpVersion.Matches(d.config.PhpVersion) {
+		return false
+	}
+	if rule.InsideFunc != nil {
+		name := d.ctx.st.CurrentFunction
+		if name == "" || !rule.InsideFunc.MatchString(name) {
+			return false
+		}
+	}
+	if rule.InsideClass != nil {
+		name := d.ctx.st.CurrentClass
+		if name == "" || !rule.InsideClass.MatchString(name) {
+			return false
+		}
+	}
+	if rule.InsideTry || rule.NotInsideTry {
+		if insideTry(n, path) != rule.InsideTry {
+			return false
+		}
+	}
+	if rule.InsideLoop && !insideLoop(n, path) {
+		return false
+	}
+	return true
+}
+
 func (d *rootWalker) checkTypeFilter(wantType *phpdoc.Type, sc *meta.Scope, nn ir.Node) bool {
 	if wantType == nil {
 		return true
@@ -2002,6 +2192,38 @@ func (d *rootWalker) checkTypeFilter(wantType *phpdoc.Type, sc *meta.Scope, nn i
 	return rules.TypeIsCompatible(wantType.Expr, haveType.Expr)
 }
 
+// checkRelation reports whether the rel relation between x and y holds.
+func (d *rootWalker) checkRelation(rel rules.Relation, sc *meta.Scope, x, y ir.Node) bool {
+	var result bool
+	switch rel.Op {
+	case rules.RelationSameText:
+		result = d.nodeText(x) == d.nodeText(y)
+
+	case rules.RelationSameValue:
+		xv := constfold.Eval(d.ctx.st, x)
+		yv := constfold.Eval(d.ctx.st, y)
+		if !xv.IsValid() || !yv.IsValid() {
+			return false
+		}
+		result = xv.IsEqual(yv)
+
+	case rules.RelationSameType, rules.RelationSubtypeOf:
+		xt := solver.ExprType(sc, d.ctx.st, x)
+		yt := solver.ExprType(sc, d.ctx.st, y)
+		// We can't tell anything about the unknown types.
+		if xt.Empty() || yt.Empty() || isMixedLikeTypes(xt) || isMixedLikeTypes(yt) {
+			return false
+		}
+		if rel.Op == rules.RelationSameType {
+			result = xt.Equals(yt)
+		} else {
+			result = isSubtypeOf(d.metaInfo(), xt, yt)
+		}
+	}
+
+	return result != rel.Negated
+}
+
 func (d *rootWalker) checkFilterSet(m *phpgrep.MatchData, sc *meta.Scope, filterSet map[string]rules.Filter) (bool, error) {
 	// TODO: pass custom types here, so both @type and @pure predicates can use it.
 
@@ -2017,6 +2239,20 @@ func (d *rootWalker) checkFilterSet(m *phpgrep.MatchData, sc *meta.Scope, filter
 		if filter.Pure && !solver.SideEffectFree(d.scope(), d.ctx.st, nil, nn) {
 			return false, nil
 		}
+		if len(filter.Values) != 0 {
+			value := constfold.Eval(d.ctx.st, nn)
+			for _, c := range filter.Values {
+				if !c.Matches(value) {
+					return false, nil
+				}
+			}
+		}
+		for _, rel := range filter.Relations {
+			other, ok := m.CapturedByName(rel.Other)
+			if !ok || !d.checkRelation(rel, sc, nn, other) {
+				return false, nil
+			}
+		}
 		if filter.Regexp != nil {
 			switch v := nn.(type) {
 			case *ir.SimpleVar:
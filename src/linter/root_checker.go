@@ -34,6 +34,10 @@ type rootChecker struct {
 	// See github.com/client9/misspell for details.
 	typoFixer *misspell.Replacer
 
+	// See Config.UnusedParams and Config.UnusedPrivateMembers.
+	unusedParams         bool
+	unusedPrivateMembers bool
+
 	quickfix *QuickFixGenerator
 }
 
@@ -51,6 +55,8 @@ func newRootChecker(walker *rootWalker, quickfix *QuickFixGenerator) *rootChecke
 	}
 	if walker.config != nil {
 		c.typoFixer = walker.config.TypoFixer
+		c.unusedParams = walker.config.UnusedParams
+		c.unusedPrivateMembers = walker.config.UnusedPrivateMembers
 	}
 	return c
 }
@@ -85,7 +91,8 @@ func (r *rootChecker) CheckFunction(fun *ir.FunctionStmt) bool {
 	funcParams := r.walker.parseFuncParams(fun.Params, phpDocParamTypes, sc, nil)
 	r.CheckFuncParams(fun.FunctionName, fun.Params, funcParams, phpDocParamTypes)
 
-	r.walker.handleFuncStmts(funcParams.params, nil, fun.Stmts, sc)
+	funcInfo := r.walker.handleFuncStmts(funcParams.params, nil, fun.Stmts, sc)
+	r.CheckUnusedParams(fun.Params, funcInfo.unusedParams)
 
 	return false
 }
@@ -388,10 +395,14 @@ func (r *rootChecker) checkUndefinedClass(className string, part phpdoc.CommentP
 		partNum = 2
 	}
 
-	r.walker.ReportPHPDoc(PHPDocLineField(n, part.Line(), partNum),
-		LevelError, "undefinedClass",
-		"Class or interface named %s does not exist", className,
-	)
+	r.walker.reportWithSuggestion(func() string {
+		return r.walker.suggestClass(className)
+	}, func() {
+		r.walker.ReportPHPDoc(PHPDocLineField(n, part.Line(), partNum),
+			LevelError, "undefinedClass",
+			"Class or interface named %s does not exist", className,
+		)
+	})
 }
 
 func (r *rootChecker) isValidPHPDocRef(ref string) bool {
@@ -573,9 +584,14 @@ func (r *rootChecker) CheckTypeHintNode(n ir.Node, place string) {
 			class, hasClass := r.info.GetClass(className)
 
 			if !hasClass && !hasTrait {
-				r.walker.Report(n, LevelError, "undefinedClass",
-					"Class or interface named %s does not exist", className,
-				)
+				r.walker.reportWithSuggestion(func() string {
+					return r.walker.suggestClass(className)
+				}, func() {
+					r.walker.Report(n, LevelError, "undefinedClass",
+						"Class or interface named %s does not exist", className,
+					)
+				})
+				r.walker.addImportQuickFix(n, className)
 			}
 
 			r.CheckNameCase(n, className, class.Name)
@@ -591,6 +607,76 @@ func (r *rootChecker) CheckFuncParams(funcName *ir.Identifier, params []ir.Node,
 	r.checkParamsTypeHint(funcName, funcParams, phpDocParamTypes)
 }
 
+// CheckMethodUnusedParams reports method params that are never read.
+//
+// Methods that override a parent method or implement an interface
+// method are skipped since their signature is dictated by the base
+// declaration. Trait methods and magic methods are skipped for the same reason.
+func (r *rootChecker) CheckMethodUnusedParams(meth *ir.ClassMethodStmt, unused map[string]struct{}) {
+	if !r.unusedParams || !r.info.IsIndexingComplete() || len(unused) == 0 {
+		return
+	}
+	if r.state.IsTrait || r.state.IsInterface {
+		return
+	}
+
+	name := meth.MethodName.Value
+	if strings.HasPrefix(name, "__") && !strings.EqualFold(name, "__construct") {
+		return
+	}
+	if r.isMethodOverride(r.state.CurrentClass, name) {
+		return
+	}
+
+	r.CheckUnusedParams(meth.Params, unused)
+}
+
+// CheckUnusedParams reports params from the unused set in their declaration order.
+func (r *rootChecker) CheckUnusedParams(params []ir.Node, unused map[string]struct{}) {
+	if !r.unusedParams || !r.info.IsIndexingComplete() || len(unused) == 0 {
+		return
+	}
+
+	for _, p := range params {
+		p, ok := p.(*ir.Parameter)
+		if !ok {
+			continue
+		}
+		if len(p.Modifiers) != 0 {
+			// Promoted constructor params are class properties.
+			continue
+		}
+		if _, ok := unused[p.Variable.Name]; !ok {
+			continue
+		}
+		r.walker.Report(p.Variable, LevelNotice, "unusedParam", "Param $%s is unused (specify --unused-var-regex flag to ignore such params)", p.Variable.Name)
+	}
+}
+
+// isMethodOverride reports whether the method with the given name is
+// declared in any parent class or interface of the className class.
+func (r *rootChecker) isMethodOverride(className, methodName string) bool {
+	class, ok := r.info.GetClass(className)
+	if !ok {
+		// Anonymous classes and classes we know nothing about.
+		return true
+	}
+
+	if class.Parent != "" {
+		if _, ok := solver.FindMethod(r.info, class.Parent, methodName); ok {
+			return true
+		}
+	}
+
+	for iface := range class.Interfaces {
+		if _, ok := solver.FindMethod(r.info, iface, methodName); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r *rootChecker) checkFuncParam(p *ir.Parameter) {
 	r.CheckVarNameMisspellings(p, p.Variable.Name)
 
@@ -933,10 +1019,12 @@ func (r *rootChecker) checkImplemented(classNode, name ir.Node, nameUsed string,
 
 	r.CheckNameCase(name, nameUsed, otherClass.Name)
 	visited := make(map[string]struct{}, 4)
-	r.checkImplementedStep(classNode, name, nameUsed, otherClass, visited)
+	var unimplemented []meta.FuncInfo
+	r.checkImplementedStep(classNode, name, nameUsed, otherClass, visited, &unimplemented)
+	r.addMethodStubsQuickFix(classNode, unimplemented)
 }
 
-func (r *rootChecker) checkImplementedStep(classNode, name ir.Node, className string, otherClass meta.ClassInfo, visited map[string]struct{}) {
+func (r *rootChecker) checkImplementedStep(classNode, name ir.Node, className string, otherClass meta.ClassInfo, visited map[string]struct{}, unimplemented *[]meta.FuncInfo) {
 	// TODO: check that method signatures are compatible?
 	if _, ok := visited[className]; ok {
 		return
@@ -948,6 +1036,7 @@ func (r *rootChecker) checkImplementedStep(classNode, name ir.Node, className st
 		if !ok || !m.Implemented {
 			r.walker.Report(name, LevelError, "unimplemented", "Class %s must implement %s::%s method",
 				r.state.CurrentClass, className, ifaceMethod.Name)
+			*unimplemented = append(*unimplemented, ifaceMethod)
 			continue
 		}
 		if m.Info.Name != ifaceMethod.Name {
@@ -968,20 +1057,115 @@ func (r *rootChecker) checkImplementedStep(classNode, name ir.Node, className st
 	for _, ifaceName := range otherClass.ParentInterfaces {
 		iface, ok := r.info.GetClass(ifaceName)
 		if ok {
-			r.checkImplementedStep(classNode, name, ifaceName, iface, visited)
+			r.checkImplementedStep(classNode, name, ifaceName, iface, visited, unimplemented)
 		}
 	}
 
 	if otherClass.Parent != "" {
 		class, ok := r.info.GetClass(otherClass.Parent)
 		if ok {
-			r.checkImplementedStep(classNode, name, otherClass.Parent, class, visited)
+			r.checkImplementedStep(classNode, name, otherClass.Parent, class, visited, unimplemented)
 		}
 	}
 }
 
+// CheckUnusedPrivateMembers reports private methods, properties and constants
+// of the class that are never referenced inside the class or its traits.
+func (r *rootChecker) CheckUnusedPrivateMembers(class *ir.ClassStmt) {
+	if !r.unusedPrivateMembers || !r.info.IsIndexingComplete() {
+		return
+	}
+
+	refs := make(memberRefs)
+	for _, stmt := range class.Stmts {
+		collectMemberRefs(refs, stmt)
+	}
+
+	classInfo, ok := r.info.GetClass(r.state.CurrentClass)
+	if ok {
+		r.collectTraitsMemberRefs(refs, classInfo, make(map[string]struct{}))
+	}
+
+	for _, stmt := range class.Stmts {
+		switch stmt := stmt.(type) {
+		case *ir.ClassMethodStmt:
+			name := stmt.MethodName.Value
+			if strings.EqualFold(name, "__construct") {
+				r.checkUnusedPromotedProperties(class, stmt, refs)
+			}
+			if !hasModifier(stmt.Modifiers, "private") || len(stmt.AttrGroups) != 0 {
+				continue
+			}
+			if strings.HasPrefix(name, "__") || refs.hasMethod(name) {
+				continue
+			}
+			r.walker.Report(stmt.MethodName, LevelWarning, "unusedPrivateMember", "Private method %s::%s is unused", class.ClassName.Value, name)
+
+		case *ir.PropertyListStmt:
+			if !hasModifier(stmt.Modifiers, "private") || len(stmt.AttrGroups) != 0 {
+				continue
+			}
+			for _, p := range stmt.Properties {
+				p := p.(*ir.PropertyStmt)
+				if refs.hasProperty(p.Variable.Name) {
+					continue
+				}
+				r.walker.Report(p.Variable, LevelWarning, "unusedPrivateMember", "Private property %s::$%s is unused", class.ClassName.Value, p.Variable.Name)
+			}
+
+		case *ir.ClassConstListStmt:
+			if !hasModifier(stmt.Modifiers, "private") {
+				continue
+			}
+			for _, c := range stmt.Consts {
+				c := c.(*ir.ConstantStmt)
+				if refs.hasConstant(c.ConstantName.Value) {
+					continue
+				}
+				r.walker.Report(c.ConstantName, LevelWarning, "unusedPrivateMember", "Private constant %s::%s is unused", class.ClassName.Value, c.ConstantName.Value)
+			}
+		}
+	}
+}
+
+func (r *rootChecker) checkUnusedPromotedProperties(class *ir.ClassStmt, ctor *ir.ClassMethodStmt, refs memberRefs) {
+	for _, p := range ctor.Params {
+		p, ok := p.(*ir.Parameter)
+		if !ok || !hasModifier(p.Modifiers, "private") || len(p.AttrGroups) != 0 {
+			continue
+		}
+		if refs.hasProperty(p.Variable.Name) {
+			continue
+		}
+		r.walker.Report(p.Variable, LevelWarning, "unusedPrivateMember", "Private property %s::$%s is unused", class.ClassName.Value, p.Variable.Name)
+	}
+}
+
+func (r *rootChecker) collectTraitsMemberRefs(refs memberRefs, class meta.ClassInfo, visited map[string]struct{}) {
+	for traitName := range class.Traits {
+		if _, ok := visited[traitName]; ok {
+			continue
+		}
+		visited[traitName] = struct{}{}
+
+		trait, ok := r.info.GetTrait(traitName)
+		if !ok {
+			continue
+		}
+		for key := range trait.UsedMembers {
+			refs[key] = struct{}{}
+		}
+		r.collectTraitsMemberRefs(refs, trait, visited)
+	}
+}
+
 func (r *rootChecker) ReportUndefinedClass(n ir.Node, name string) {
-	r.walker.Report(n, LevelError, "undefinedClass", "Class or interface named %s does not exist", name)
+	r.walker.reportWithSuggestion(func() string {
+		return r.walker.suggestClass(name)
+	}, func() {
+		r.walker.Report(n, LevelError, "undefinedClass", "Class or interface named %s does not exist", name)
+	})
+	r.walker.addImportQuickFix(n, name)
 }
 
 func (r *rootChecker) ReportUndefinedTrait(n ir.Node, name string) {
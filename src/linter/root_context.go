@@ -1,8 +1,6 @@
 package linter
 
 import (
-	"path/filepath"
-
 	"github.com/VKCOM/noverify/src/baseline"
 	"github.com/VKCOM/noverify/src/ir"
 	"github.com/VKCOM/noverify/src/meta"
@@ -25,13 +23,26 @@ type rootContext struct {
 	hashCounters map[uint64]int // Allocated lazily
 
 	fixes []quickfix.TextEdit
+
+	// importPos is a position after which a new use statement
+	// can be inserted, 0 if there is no such position yet.
+	// importPrefix is inserted before the new use statement.
+	importPos    int
+	importPrefix string
+
+	// imports is a set of class names imported by quickfixes. Allocated lazily.
+	imports map[string]struct{}
+
+	// stubbedMethods is a set of Class::method names for which
+	// the method stubs were generated by quickfixes. Allocated lazily.
+	stubbedMethods map[string]struct{}
 }
 
 func newRootContext(config *Config, workerCtx *WorkerContext, st *meta.ClassParseState) rootContext {
 	var p baseline.FileProfile
 	if config.BaselineProfile != nil {
-		filename := filepath.Base(st.CurrentFile)
-		p = config.BaselineProfile.Files[filename]
+		filename := baseline.RelativePath(config.BaselineRoot, st.CurrentFile)
+		p = config.BaselineProfile.Lookup(filename)
 	}
 
 	classFQNProvider := func(name string) (string, bool) {
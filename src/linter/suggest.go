@@ -0,0 +1,277 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/VKCOM/noverify/src/meta"
+	"github.com/VKCOM/noverify/src/types"
+)
+
+// nameSuggester finds the most similar name among the given candidates.
+//
+// Names are compared case-insensitively, since most of the PHP symbols
+// are case-insensitive and a wrong case is a common typo anyway.
+type nameSuggester struct {
+	target  string
+	maxDist int
+
+	best     string
+	bestDist int
+}
+
+func newNameSuggester(name string) *nameSuggester {
+	// Allow 1 typo for short names and up to 3 typos for long names.
+	maxDist := len(lastNamePart(name)) / 3
+	if maxDist < 1 {
+		maxDist = 1
+	} else if maxDist > 3 {
+		maxDist = 3
+	}
+	return &nameSuggester{
+		target:   strings.ToLower(name),
+		maxDist:  maxDist,
+		bestDist: maxDist + 1,
+	}
+}
+
+func (s *nameSuggester) add(candidate string) {
+	s.addAs(candidate, candidate)
+}
+
+// addAs compares the target with the name, but suggests the candidate,
+// like a fully qualified class name for the unqualified one.
+func (s *nameSuggester) addAs(name, candidate string) {
+	diff := len(name) - len(s.target)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > s.maxDist {
+		return
+	}
+
+	dist := editDistance(s.target, strings.ToLower(name))
+	if dist == 0 {
+		return
+	}
+	// Map iteration order is random, so we choose
+	// the lexicographically smallest name among the equals.
+	if dist < s.bestDist || (dist == s.bestDist && candidate < s.best) {
+		s.best = candidate
+		s.bestDist = dist
+	}
+}
+
+func (s *nameSuggester) suggestion() string {
+	return s.best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// lastNamePart returns the unqualified part of the symbol name.
+func lastNamePart(name string) string {
+	if i := strings.LastIndexByte(name, '\\'); i != -1 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// didYouMean formats the suggestion to be appended to the report message.
+func didYouMean(suggestion string) string {
+	if suggestion == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %s?)", suggestion)
+}
+
+// suggestClass returns the class name that is similar to className.
+//
+// Classes are compared by the unqualified names, so a typo
+// in the name of a class from another namespace is found too.
+func (d *rootWalker) suggestClass(className string) string {
+	shortName := lastNamePart(className)
+	s := newNameSuggester(shortName)
+	var sameShortName []string
+	d.forEachNamedClass(func(class meta.ClassInfo) {
+		classShortName := lastNamePart(class.Name)
+		if strings.EqualFold(classShortName, shortName) {
+			sameShortName = append(sameShortName, class.Name)
+			return
+		}
+		s.addAs(classShortName, class.Name)
+	})
+
+	switch len(sameShortName) {
+	case 0:
+		return s.suggestion()
+	case 1:
+		return sameShortName[0]
+	default:
+		sort.Strings(sameShortName)
+		return strings.Join(sameShortName, " or ")
+	}
+}
+
+// importableClass returns the name of the only class with
+// the same unqualified name as className, so it can be
+// imported by the use statement.
+func (d *rootWalker) importableClass(className string) string {
+	shortName := lastNamePart(className)
+	var importName string
+	found := 0
+	d.forEachNamedClass(func(class meta.ClassInfo) {
+		if strings.EqualFold(lastNamePart(class.Name), shortName) {
+			importName = class.Name
+			found++
+		}
+	})
+	if found != 1 {
+		return ""
+	}
+	return importName
+}
+
+// forEachNamedClass calls cb for every class that is not auto-generated.
+func (d *rootWalker) forEachNamedClass(cb func(class meta.ClassInfo)) {
+	d.metaInfo().ForEachClass(func(class meta.ClassInfo) {
+		if class.IsShape() || strings.ContainsAny(class.Name, "$@") {
+			return // Auto-generated names
+		}
+		cb(class)
+	})
+}
+
+// suggestFunction returns the function name that is similar to funcName.
+func (d *rootWalker) suggestFunction(funcName string) string {
+	s := newNameSuggester(funcName)
+	d.metaInfo().ForEachFunction(func(fn meta.FuncInfo) {
+		if types.IsClosure(fn.Name) {
+			return
+		}
+		s.add(fn.Name)
+	})
+	return s.suggestion()
+}
+
+// suggestConstant returns the global constant name that is similar to constName.
+func (d *rootWalker) suggestConstant(constName string) string {
+	if !strings.HasPrefix(constName, `\`) {
+		constName = `\` + constName
+	}
+	s := newNameSuggester(constName)
+	d.metaInfo().ForEachConstant(func(name string, _ meta.ConstInfo) {
+		s.add(name)
+	})
+	return s.suggestion()
+}
+
+// suggestMethod returns the method name that is similar to methodName.
+// All classes from the classNames and their parents are searched.
+func (d *rootWalker) suggestMethod(classNames []string, methodName string) string {
+	s := newNameSuggester(methodName)
+	d.walkClassHierarchy(classNames, func(class meta.ClassInfo) {
+		for _, method := range class.Methods.H {
+			s.add(method.Name)
+		}
+	})
+	return s.suggestion()
+}
+
+// suggestProperty returns the property name that is similar to propName.
+// Static properties are prefixed with "$" in both propName and the class info.
+func (d *rootWalker) suggestProperty(classNames []string, propName string) string {
+	s := newNameSuggester(propName)
+	d.walkClassHierarchy(classNames, func(class meta.ClassInfo) {
+		for name := range class.Properties {
+			if strings.HasPrefix(name, "$") == strings.HasPrefix(propName, "$") {
+				s.add(name)
+			}
+		}
+	})
+	return s.suggestion()
+}
+
+// suggestClassConstant returns the class constant name that is similar to constName.
+func (d *rootWalker) suggestClassConstant(classNames []string, constName string) string {
+	s := newNameSuggester(constName)
+	d.walkClassHierarchy(classNames, func(class meta.ClassInfo) {
+		for name := range class.Constants {
+			s.add(name)
+		}
+	})
+	return s.suggestion()
+}
+
+// walkClassHierarchy calls cb for every class from the classNames,
+// as well as for their parents, interfaces and traits.
+func (d *rootWalker) walkClassHierarchy(classNames []string, cb func(class meta.ClassInfo)) {
+	visited := make(map[string]struct{})
+	var walk func(className string)
+	walk = func(className string) {
+		if _, ok := visited[className]; ok {
+			return
+		}
+		visited[className] = struct{}{}
+
+		class, ok := d.metaInfo().GetClassOrTrait(className)
+		if !ok {
+			return
+		}
+		cb(class)
+
+		if class.Parent != "" {
+			walk(class.Parent)
+		}
+		for _, iface := range class.ParentInterfaces {
+			walk(iface)
+		}
+		for iface := range class.Interfaces {
+			walk(iface)
+		}
+		for trait := range class.Traits {
+			walk(trait)
+		}
+	}
+
+	for _, className := range classNames {
+		walk(className)
+	}
+}
+
+// classNamesOf returns all class names from the type map.
+func classNamesOf(typ types.Map) []string {
+	var classNames []string
+	typ.Iterate(func(t string) {
+		if types.IsClass(t) {
+			classNames = append(classNames, t)
+		}
+	})
+	return classNames
+}
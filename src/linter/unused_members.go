@@ -0,0 +1,121 @@
+package linter
+
+import (
+	"strings"
+
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irutil"
+)
+
+// memberRefs is a set of class member names that are referenced
+// inside some class-like body.
+//
+// Keys are encoded in a way that makes different member kinds distinct:
+//
+//	foo()  - method foo (lowercased, since method names are case-insensitive)
+//	$foo   - instance or static property foo
+//	FOO    - class constant FOO
+//
+// Dynamic accesses like $this->$name are recorded as "*()" for methods
+// and "$*" for properties, which means that any member of that kind can be used.
+type memberRefs map[string]struct{}
+
+const (
+	dynamicMethodRef   = "*()"
+	dynamicPropertyRef = "$*"
+)
+
+func (refs memberRefs) addMethod(name string) {
+	refs[strings.ToLower(name)+"()"] = struct{}{}
+}
+
+func (refs memberRefs) addProperty(name string) {
+	refs["$"+name] = struct{}{}
+}
+
+func (refs memberRefs) addConstant(name string) {
+	refs[name] = struct{}{}
+}
+
+func (refs memberRefs) hasMethod(name string) bool {
+	return refs.has(dynamicMethodRef) || refs.has(strings.ToLower(name)+"()")
+}
+
+func (refs memberRefs) hasProperty(name string) bool {
+	return refs.has(dynamicPropertyRef) || refs.has("$"+name)
+}
+
+func (refs memberRefs) hasConstant(name string) bool {
+	return refs.has(name)
+}
+
+func (refs memberRefs) has(key string) bool {
+	_, ok := refs[key]
+	return ok
+}
+
+// collectMemberRefs records all member names that can be referenced by n.
+//
+// We don't try to resolve the object or class being accessed:
+// $other->x inside a class method can refer to a private $x
+// of the same class, so any access by name counts.
+func collectMemberRefs(refs memberRefs, n ir.Node) {
+	irutil.Inspect(n, func(n ir.Node) bool {
+		switch n := n.(type) {
+		case *ir.PropertyFetchExpr:
+			addPropertyRef(refs, n.Property)
+		case *ir.NullsafePropertyFetchExpr:
+			addPropertyRef(refs, n.Property)
+		case *ir.StaticPropertyFetchExpr:
+			if v, ok := n.Property.(*ir.SimpleVar); ok {
+				refs.addProperty(v.Name)
+			} else {
+				refs.addProperty("*")
+			}
+		case *ir.MethodCallExpr:
+			addMethodRef(refs, n.Method)
+		case *ir.NullsafeMethodCallExpr:
+			addMethodRef(refs, n.Method)
+		case *ir.StaticCallExpr:
+			addMethodRef(refs, n.Call)
+		case *ir.ClassConstFetchExpr:
+			refs.addConstant(n.ConstantName.Value)
+		case *ir.String:
+			// Strings can be used as callables like [$this, 'method'],
+			// 'self::method' or as property names in property_exists().
+			name := n.Value
+			if i := strings.LastIndex(name, "::"); i != -1 {
+				name = name[i+len("::"):]
+			}
+			refs.addMethod(name)
+			refs.addProperty(name)
+		}
+		return true
+	})
+}
+
+func addPropertyRef(refs memberRefs, prop ir.Node) {
+	if id, ok := prop.(*ir.Identifier); ok {
+		refs.addProperty(id.Value)
+	} else {
+		refs.addProperty("*")
+	}
+}
+
+func addMethodRef(refs memberRefs, method ir.Node) {
+	if id, ok := method.(*ir.Identifier); ok {
+		refs.addMethod(id.Value)
+	} else {
+		refs.addMethod("*")
+	}
+}
+
+// hasModifier reports whether modifiers list contains the given modifier.
+func hasModifier(modifiers []*ir.Identifier, modifier string) bool {
+	for _, m := range modifiers {
+		if strings.EqualFold(m.Value, modifier) {
+			return true
+		}
+	}
+	return false
+}
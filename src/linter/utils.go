@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irutil"
 	"github.com/VKCOM/noverify/src/meta"
 	"github.com/VKCOM/noverify/src/phpdoc"
 	"github.com/VKCOM/noverify/src/rules"
@@ -492,27 +493,106 @@ func cloneRulesForFile(filename string, ruleSet *rules.ScopedSet) *rules.ScopedS
 
 	var clone rules.ScopedSet
 	for kind, ruleByKind := range &ruleSet.RulesByKind {
-		res := make([]rules.Rule, 0, len(ruleByKind))
-		for _, rule := range ruleByKind {
-			if isFilePathExcluded(filename, rule) {
-				continue
-			}
+		clone.Set(ir.NodeKind(kind), filterRulesForFile(filename, ruleByKind))
+	}
+	clone.SetSequences(filterRulesForFile(filename, ruleSet.Sequences))
+	return &clone
+}
+
+func filterRulesForFile(filename string, list []rules.Rule) []rules.Rule {
+	res := make([]rules.Rule, 0, len(list))
+	for _, rule := range list {
+		if isFilePathExcluded(filename, rule) {
+			continue
+		}
+
+		if rule.Paths == nil {
+			res = append(res, rule)
+			continue
+		}
 
-			if rule.Paths == nil {
+		for _, path := range rule.Paths {
+			if strings.Contains(filename, path) {
 				res = append(res, rule)
-				continue
+				break
 			}
+		}
+	}
+	return res
+}
 
-			for _, path := range rule.Paths {
-				if strings.Contains(filename, path) {
-					res = append(res, rule)
-					break
-				}
+// insideTry reports whether n is located inside a try block.
+// The catch and finally clauses are not a part of the try block.
+func insideTry(n ir.Node, path irutil.NodePath) bool {
+	inHandler := false
+	for i := 0; path.NthParent(i) != nil; i++ {
+		switch p := path.NthParent(i).(type) {
+		case *ir.CatchStmt, *ir.FinallyStmt:
+			inHandler = true
+		case *ir.TryStmt:
+			if !inHandler && p != n {
+				return true
 			}
+			inHandler = false
 		}
-		clone.Set(ir.NodeKind(kind), res)
 	}
-	return &clone
+	return false
+}
+
+// insideLoop reports whether n is located inside a loop.
+func insideLoop(n ir.Node, path irutil.NodePath) bool {
+	for i := 0; path.NthParent(i) != nil; i++ {
+		p := path.NthParent(i)
+		if p == n {
+			continue
+		}
+		switch p.(type) {
+		case *ir.ForStmt, *ir.ForeachStmt, *ir.WhileStmt, *ir.DoStmt:
+			return true
+		}
+	}
+	return false
+}
+
+// nodeStmts returns the statements list of n, if it has one.
+func nodeStmts(n ir.Node) ([]ir.Node, bool) {
+	switch n := n.(type) {
+	case *ir.Root:
+		return n.Stmts, true
+	case *ir.StmtList:
+		return n.Stmts, true
+	case *ir.NamespaceStmt:
+		return n.Stmts, true
+	case *ir.CaseStmt:
+		return n.Stmts, true
+	case *ir.DefaultStmt:
+		return n.Stmts, true
+	case *ir.TryStmt:
+		return n.Stmts, true
+	case *ir.CatchStmt:
+		return n.Stmts, true
+	case *ir.FinallyStmt:
+		return n.Stmts, true
+	default:
+		return nil, false
+	}
+}
+
+// isSubtypeOf reports whether every type of x is one of the y types
+// or a class that extends or implements one of them.
+func isSubtypeOf(info *meta.Info, x, y types.Map) bool {
+	return !x.Find(func(xt string) bool {
+		isSubtype := y.Find(func(yt string) bool {
+			if xt == yt {
+				return true
+			}
+			if !types.IsClass(xt) || !types.IsClass(yt) {
+				return false
+			}
+			return solver.Extends(info, xt, yt) || solver.Implements(info, xt, yt)
+		})
+		return !isSubtype
+	})
 }
 
 func isMixedLikeTypes(typ types.Map) bool {
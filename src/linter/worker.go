@@ -319,7 +319,11 @@ func (w *Worker) analyzeFile(file *workspace.File, rootNode *ir.Root) (*rootWalk
 		needApplyFixes := !file.AutoGenerated() || w.config.CheckAutoGenerated
 
 		if needApplyFixes {
-			if err := quickfix.Apply(file.Name(), file.Contents(), walker.ctx.fixes); err != nil {
+			applyFixes := quickfix.Apply
+			if w.config.QuickFixHandler != nil {
+				applyFixes = w.config.QuickFixHandler
+			}
+			if err := applyFixes(file.Name(), file.Contents(), walker.ctx.fixes); err != nil {
 				linterError(file.Name(), "apply quickfix: %v", err)
 			}
 		}
@@ -244,6 +244,13 @@ func (s *Suite) RunRulesTest() {
 		s.t.Fatalf("parse rules: %v", err)
 	}
 
+	s.RunRuleSetTest(rset)
+}
+
+// RunRuleSetTest is like RunRulesTest, but it uses already parsed rules.
+func (s *Suite) RunRuleSetTest(rset *rules.Set) {
+	s.t.Helper()
+
 	s.Config().Rules = rset
 	s.IgnoreUndeclaredChecks()
 
@@ -196,6 +196,27 @@ func (i *Info) FindConstants(substr string) (res []string) {
 	return res
 }
 
+// ForEachClass calls cb for every known class and interface.
+func (i *Info) ForEachClass(cb func(class ClassInfo)) {
+	for _, class := range i.allClasses.H {
+		cb(class)
+	}
+}
+
+// ForEachFunction calls cb for every known function.
+func (i *Info) ForEachFunction(cb func(fn FuncInfo)) {
+	for _, fn := range i.allFunctions.H {
+		cb(fn)
+	}
+}
+
+// ForEachConstant calls cb for every known global constant.
+func (i *Info) ForEachConstant(cb func(name string, c ConstInfo)) {
+	for name, c := range i.allConstants {
+		cb(name, c)
+	}
+}
+
 func (i *Info) InitKphpStubs() {
 	i.internalFunctions.H[`\array_first_value`] = FuncInfo{
 		Name:         `\array_first_value`,
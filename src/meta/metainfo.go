@@ -118,6 +118,13 @@ type FuncParam struct {
 	IsRef bool
 	Name  string
 	Typ   types.Map
+
+	// Hint and Default are the source code of the param type hint
+	// and default value with all class names fully qualified.
+	// They're set only for the abstract and interface methods params
+	// and are empty if the param has no type hint or default value.
+	Hint    string
+	Default string
 }
 
 type FuncInfo struct {
@@ -131,6 +138,11 @@ type FuncInfo struct {
 	ExitFlags    int // if function has exit/die/throw, then ExitFlags will be <> 0
 	Internal     bool
 
+	// ReturnHint is the source code of the return type hint
+	// with all class names fully qualified, if any.
+	// Like the FuncParam.Hint, it's set only for the abstract methods.
+	ReturnHint string
+
 	DeprecationInfo
 }
 
@@ -216,6 +228,11 @@ type ClassInfo struct {
 	Constants        ConstantsMap
 	Mixins           []string
 
+	// UsedMembers is a set of member names that are referenced
+	// inside a trait body; it's only collected for traits.
+	// See linter.memberRefs for the keys format.
+	UsedMembers map[string]struct{}
+
 	PackageInfo
 	DeprecationInfo
 }
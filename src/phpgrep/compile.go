@@ -113,6 +113,24 @@ func (c *compiler) EnterNode(n ir.Node) bool {
 		v.Expr = anyConst{metaNode{name: name}}
 	case "func":
 		v.Expr = anyFunc{metaNode{name: name}}
+	case "array":
+		v.Expr = anyArray{metaNode{name: name}}
+	case "new":
+		v.Expr = anyNew{metaNode{name: name}}
+	case "lit":
+		v.Expr = anyLit{metaNode{name: name}}
+	case "name":
+		v.Expr = anyName{metaNode{name: name}}
+	case "class-const":
+		v.Expr = anyClassConst{metaNode{name: name}}
+	case "method-call":
+		v.Expr = anyMethodCall{metaNode{name: name}}
+	case "static-call":
+		v.Expr = anyStaticCall{metaNode{name: name}}
+	case "closure-or-arrow":
+		v.Expr = anyClosureArrow{metaNode{name: name}}
+	case "stmt":
+		v.Expr = anyStmt{metaNode{name: name}}
 	default:
 		c.err = fmt.Errorf("unknown matcher class '%s'", class)
 		return false
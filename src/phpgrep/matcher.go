@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/VKCOM/php-parser/pkg/position"
+
 	"github.com/VKCOM/noverify/src/ir"
 	"github.com/VKCOM/noverify/src/ir/irutil"
 	"github.com/VKCOM/noverify/src/ir/phpcore"
@@ -45,6 +47,63 @@ func (m *matcher) match(state *matcherState, n ir.Node) (data MatchData, ok bool
 	return data, true
 }
 
+func (m *matcher) matchSeq(state *matcherState, stmts []ir.Node) (data MatchData, ok bool) {
+	list, ok := m.root.(*ir.StmtList)
+	if !ok {
+		return data, false
+	}
+	state.capture = state.capture[:0]
+	n, ok := m.eqNodeSlicePrefix(state, list.Stmts, stmts)
+	if !ok || n == 0 {
+		return data, false
+	}
+	matched := stmts[:n]
+	first := getNodePos(matched[0])
+	last := getNodePos(matched[n-1])
+	if first == nil || last == nil {
+		return data, false
+	}
+	data.Node = &ir.StmtList{
+		Position: &position.Position{
+			StartLine: first.StartLine,
+			EndLine:   last.EndLine,
+			StartPos:  first.StartPos,
+			EndPos:    last.EndPos,
+		},
+		Stmts: matched,
+	}
+	data.Capture = state.capture
+	return data, true
+}
+
+// eqNodeSlicePrefix matches xs against the beginning of ys.
+// It returns the number of ys elements that were consumed by the match.
+//
+// Unlike eqNodeSlice, every ${"*"} tries all possible gap lengths,
+// starting from the shortest one.
+func (m *matcher) eqNodeSlicePrefix(state *matcherState, xs, ys []ir.Node) (int, bool) {
+	if len(xs) == 0 {
+		return 0, true
+	}
+
+	if matchMetaVar(xs[0], "*") {
+		numCaptured := len(state.capture)
+		for gap := 0; gap <= len(ys); gap++ {
+			if n, ok := m.eqNodeSlicePrefix(state, xs[1:], ys[gap:]); ok {
+				return gap + n, true
+			}
+			state.capture = state.capture[:numCaptured]
+		}
+		return 0, false
+	}
+
+	if len(ys) == 0 || !m.eqNode(state, xs[0], ys[0]) {
+		return 0, false
+	}
+	n, ok := m.eqNodeSlicePrefix(state, xs[1:], ys[1:])
+	return n + 1, ok
+}
+
 func (m *matcher) eqNodeSliceNoMeta(state *matcherState, xs, ys []ir.Node) bool {
 	if len(xs) != len(ys) {
 		return false
@@ -260,6 +319,11 @@ func (m *matcher) eqNode(state *matcherState, x, y ir.Node) bool {
 		if x, ok := x.Expr.(*ir.SimpleVar); ok {
 			return m.eqSimpleVar(state, x, y)
 		}
+		if v, ok := x.Expr.(*ir.Var); ok && !state.literalMatch {
+			if vn, ok := v.Expr.(anyStmt); ok {
+				return nodeIsStmt(y) && m.matchNamed(state, vn.name, y)
+			}
+		}
 		y, ok := y.(*ir.ExpressionStmt)
 		return ok && m.eqNode(state, x.Expr, y.Expr)
 
@@ -921,6 +985,44 @@ func (m *matcher) eqVar(state *matcherState, x *ir.Var, y ir.Node) bool {
 		default:
 			return false
 		}
+	case anyArray:
+		_, ok := y.(*ir.ArrayExpr)
+		return ok && m.matchNamed(state, vn.name, y)
+	case anyNew:
+		_, ok := y.(*ir.NewExpr)
+		return ok && m.matchNamed(state, vn.name, y)
+	case anyLit:
+		return nodeIsLiteral(y) && m.matchNamed(state, vn.name, y)
+	case anyName:
+		switch y.(type) {
+		case *ir.Name, *ir.Identifier:
+			return m.matchNamed(state, vn.name, y)
+		default:
+			return false
+		}
+	case anyClassConst:
+		_, ok := y.(*ir.ClassConstFetchExpr)
+		return ok && m.matchNamed(state, vn.name, y)
+	case anyMethodCall:
+		switch y.(type) {
+		case *ir.MethodCallExpr, *ir.NullsafeMethodCallExpr:
+			return m.matchNamed(state, vn.name, y)
+		default:
+			return false
+		}
+	case anyStaticCall:
+		_, ok := y.(*ir.StaticCallExpr)
+		return ok && m.matchNamed(state, vn.name, y)
+	case anyClosureArrow:
+		switch y.(type) {
+		case *ir.ClosureExpr, *ir.ArrowFunctionExpr:
+			return m.matchNamed(state, vn.name, y)
+		default:
+			return false
+		}
+	case anyStmt:
+		// Statements are matched by the *ir.ExpressionStmt case of eqNode.
+		return false
 	}
 
 	if y, ok := y.(*ir.Var); ok {
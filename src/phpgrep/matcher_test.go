@@ -584,6 +584,33 @@ func TestMatch(t *testing.T) {
 		{`${"call"}`, `$a[0]->method()`},
 		{`${"x:call"} + ${"x:call"}`, `f() + f()`},
 
+		{`${"array"}`, `[]`},
+		{`${"array"}`, `array(1, 2)`},
+		{`count(${"array"})`, `count([1, 2])`},
+		{`${"new"}`, `new T`},
+		{`${"new"}`, `new T(1)`},
+		{`${"new"}`, `new class {}`},
+		{`${"lit"}`, `1`},
+		{`${"lit"}`, `1.5`},
+		{`${"lit"}`, `'a'`},
+		{`${"lit"}`, `null`},
+		{`${"lit"}`, `TRUE`},
+		{`${"name"}()`, `f()`},
+		{`$_->${"name"}()`, `$o->method()`},
+		{`new ${"name"}()`, `new \Foo\Bar()`},
+		{`${"class-const"}`, `T::FOO`},
+		{`${"class-const"}`, `static::class`},
+		{`${"method-call"}`, `$o->f()`},
+		{`${"static-call"}`, `T::f()`},
+		{`${"static-call"}`, `parent::__construct()`},
+		{`${"closure-or-arrow"}`, `function() {}`},
+		{`${"closure-or-arrow"}`, `fn() => 1`},
+		{`array_map(${"closure-or-arrow"}, $_)`, `array_map(fn($x) => $x, $xs)`},
+		{`if ($_) ${"stmt"};`, `if ($c) return 1;`},
+		{`if ($_) ${"stmt"};`, `if ($c) { f(); }`},
+		{`while ($_) { ${"stmt"}; ${"stmt"}; }`, `while (1) { f(); echo 1; }`},
+		{`{ ${"x:stmt"}; ${"x:stmt"}; }`, `{ f(); f(); }`},
+
 		{`1`, `1`},
 		{`(1)`, `(1)`},
 		{`((1))`, `((1))`},
@@ -771,6 +798,25 @@ func TestMatchNegative(t *testing.T) {
 		{`${"call"}`, `$x[0]`},
 		{`${"x:call"} + ${"x:call"}`, `f() + g()`},
 
+		{`${"array"}`, `$x`},
+		{`${"array"}`, `list($x) = $y`},
+		{`${"new"}`, `T::create()`},
+		{`${"lit"}`, `MY_CONST`},
+		{`${"lit"}`, `"a$x"`},
+		{`${"lit"}`, `[1]`},
+		{`${"name"}()`, `$f()`},
+		{`new ${"name"}()`, `new $class()`},
+		{`${"class-const"}`, `MY_CONST`},
+		{`${"class-const"}`, `T::$prop`},
+		{`${"method-call"}`, `T::f()`},
+		{`${"method-call"}`, `f()`},
+		{`${"static-call"}`, `$o->f()`},
+		{`${"closure-or-arrow"}`, `f()`},
+		{`${"closure-or-arrow"}`, `'strlen'`},
+		{`${"stmt"}`, `1`},
+		{`while ($_) { ${"stmt"}; }`, `while (1) { f(); echo 1; }`},
+		{`{ ${"x:stmt"}; ${"x:stmt"}; }`, `{ f(); g(); }`},
+
 		{`(1)`, `1`},
 		{`((1))`, `(1)`},
 		{`f((1))`, `f(1)`},
@@ -793,6 +839,48 @@ func TestMatchNegative(t *testing.T) {
 	})
 }
 
+func TestMatchSeq(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    string // Matched statements, empty if no match is expected
+	}{
+		{`{ $x = $y; return $x; }`, `$a = 1; return $a;`, `$a = 1; return $a;`},
+		{`{ $x = $y; return $x; }`, `$a = 1; return $a; echo 1;`, `$a = 1; return $a;`},
+		{`{ $x = $y; return $x; }`, `$a = 1; return $b;`, ``},
+		{`{ $x = $y; return $x; }`, `echo 1; $a = 1; return $a;`, ``},
+		{`{ $x = 1; ${"*"}; $x = 2; }`, `$a = 1; $a = 2;`, `$a = 1; $a = 2;`},
+		{`{ $x = 1; ${"*"}; $x = 2; }`, `$a = 1; f(); g(); $a = 2; $a = 2;`, `$a = 1; f(); g(); $a = 2;`},
+		{`{ $x = 1; ${"*"}; $x = 2; }`, `$a = 1; f(); $b = 2;`, ``},
+		{`{ $x = 1; ${"*"}; $y = 2; echo $y; }`, `$a = 1; $b = 2; $c = 2; echo $c;`, `$a = 1; $b = 2; $c = 2; echo $c;`},
+	}
+
+	var c Compiler
+	for _, test := range tests {
+		matcher := mustCompile(t, &c, test.pattern)
+		var have string
+		if m, ok := matcher.MatchSeq(mustParseStmts(t, test.input)); ok {
+			have = irutil.FmtNode(m.Node)
+		}
+		var want string
+		if test.want != "" {
+			want = irutil.FmtNode(&ir.StmtList{Stmts: mustParseStmts(t, test.want)})
+		}
+		if have != want {
+			t.Errorf("match results mismatch:\npattern: %q\ninput: %q\nhave: %q\nwant: %q",
+				test.pattern, test.input, have, want)
+		}
+	}
+}
+
+func mustParseStmts(t *testing.T, code string) []ir.Node {
+	root, err := parseutil.ParseStmtList([]byte(code))
+	if err != nil {
+		t.Fatalf("parse %q: %v", code, err)
+	}
+	return irconv.ConvertNode(root).(*ir.Root).Stmts
+}
+
 func BenchmarkMatch(b *testing.B) {
 	runBench := func(name, pattern string, input string) {
 		b.Run(name, func(b *testing.B) {
@@ -24,4 +24,14 @@ type (
 	anyExpr  struct{ metaNode }
 	anyCall  struct{ metaNode }
 	anyFunc  struct{ metaNode }
+
+	anyArray        struct{ metaNode }
+	anyNew          struct{ metaNode }
+	anyLit          struct{ metaNode }
+	anyName         struct{ metaNode }
+	anyClassConst   struct{ metaNode }
+	anyMethodCall   struct{ metaNode }
+	anyStaticCall   struct{ metaNode }
+	anyClosureArrow struct{ metaNode }
+	anyStmt         struct{ metaNode }
 )
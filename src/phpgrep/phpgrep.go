@@ -58,3 +58,17 @@ func (m *Matcher) Match(n ir.Node) (MatchData, bool) {
 	var state matcherState
 	return m.m.match(&state, n)
 }
+
+// MatchSeq attempts to match a statement sequence pattern
+// against the statements that start at stmts[0].
+//
+// The pattern must be a statement list like `{ $x = f(); return $x; }`.
+// Pattern `${"*"};` statements match any number of statements (non-greedy).
+// Only the minimal prefix of stmts that satisfies the pattern is matched.
+//
+// The returned match data Node is a synthetic *ir.StmtList
+// that spans all matched statements.
+func (m *Matcher) MatchSeq(stmts []ir.Node) (MatchData, bool) {
+	var state matcherState
+	return m.m.matchSeq(&state, stmts)
+}
@@ -2,6 +2,7 @@ package phpgrep
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/VKCOM/php-parser/pkg/position"
 
@@ -58,6 +59,65 @@ func nodeIsVar(n ir.Node) bool {
 	}
 }
 
+// nodeIsLiteral reports whether n is a number, string,
+// true, false or null literal.
+func nodeIsLiteral(n ir.Node) bool {
+	switch n := n.(type) {
+	case *ir.Lnumber, *ir.Dnumber, *ir.String:
+		return true
+	case *ir.ConstFetchExpr:
+		switch strings.ToLower(n.Constant.Value) {
+		case "true", "false", "null":
+			return true
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// nodeIsStmt reports whether n is a statement
+// that can be a part of the statements list.
+func nodeIsStmt(n ir.Node) bool {
+	switch n.(type) {
+	case *ir.BreakStmt,
+		*ir.ClassStmt,
+		*ir.ConstListStmt,
+		*ir.ContinueStmt,
+		*ir.DeclareStmt,
+		*ir.DoStmt,
+		*ir.EchoStmt,
+		*ir.ExpressionStmt,
+		*ir.ForStmt,
+		*ir.ForeachStmt,
+		*ir.FunctionStmt,
+		*ir.GlobalStmt,
+		*ir.GotoStmt,
+		*ir.GroupUseStmt,
+		*ir.HaltCompilerStmt,
+		*ir.IfStmt,
+		*ir.InlineHTMLStmt,
+		*ir.InterfaceStmt,
+		*ir.LabelStmt,
+		*ir.NamespaceStmt,
+		*ir.NopStmt,
+		*ir.ReturnStmt,
+		*ir.StaticStmt,
+		*ir.StmtList,
+		*ir.SwitchStmt,
+		*ir.ThrowStmt,
+		*ir.TraitStmt,
+		*ir.TryStmt,
+		*ir.UnsetStmt,
+		*ir.UseListStmt,
+		*ir.WhileStmt:
+		return true
+
+	default:
+		return false
+	}
+}
+
 func nodeIsExpr(n ir.Node) bool {
 	switch n.(type) {
 	case *ir.Assign,
@@ -25,13 +25,7 @@ func Apply(filename string, contents []byte, fixes []TextEdit) error {
 		return nil
 	}
 
-	sort.Slice(fixes, func(i, j int) bool {
-		return fixes[i].StartPos < fixes[j].StartPos
-	})
-
-	var buf bytes.Buffer
-	buf.Grow(len(contents))
-	writeFixes(&buf, contents, fixes)
+	fixed := Render(contents, fixes)
 
 	// We don't want to create a file if it doesn't exist,
 	// hence using open instead of ioutil.WriteFile.
@@ -40,10 +34,23 @@ func Apply(filename string, contents []byte, fixes []TextEdit) error {
 		return err
 	}
 	defer f.Close()
-	_, err = f.Write(buf.Bytes())
+	_, err = f.Write(fixed)
 	return err
 }
 
+// Render returns the contents with the fixes applied.
+// Like Apply, it skips the fixes that are nested into other fixes.
+func Render(contents []byte, fixes []TextEdit) []byte {
+	sort.SliceStable(fixes, func(i, j int) bool {
+		return fixes[i].StartPos < fixes[j].StartPos
+	})
+
+	var buf bytes.Buffer
+	buf.Grow(len(contents))
+	writeFixes(&buf, contents, fixes)
+	return buf.Bytes()
+}
+
 func writeFixes(buf *bytes.Buffer, contents []byte, fixes []TextEdit) {
 	offset := 0
 	for _, fix := range fixes {
@@ -0,0 +1,344 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/ir/irconv"
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+	"github.com/VKCOM/noverify/src/php/parseutil"
+	"github.com/VKCOM/noverify/src/phpdoc"
+)
+
+// RuleSpec describes a rule that is created by the Builder.
+//
+// It's a Go counterpart of a rule from the rules file:
+// every field corresponds to the rule pattern or one of its attributes.
+type RuleSpec struct {
+	// Name is a checker name, like a rule function name in the rules file.
+	Name string
+
+	// Pattern is a phpgrep pattern, like `in_array($x, array_keys($arr))`.
+	// If Sequence is true, it's a block of statements, like `{ $x = f(); return $x; }`.
+	Pattern string
+
+	// Sequence makes the rule match a contiguous statements sequence, like a seq block.
+	Sequence bool
+
+	// Level is one of the lintapi.LevelError, lintapi.LevelWarning
+	// or lintapi.LevelNotice, like @error, @warning and @maybe.
+	Level int
+
+	// Message is a report text, it can refer to the pattern variables.
+	Message string
+
+	Link         string   // @link
+	Fix          string   // @fix
+	Location     string   // @location, a pattern variable name without $
+	Scope        string   // @scope: "any" (default), "root" or "local"
+	StrictSyntax bool     // @strict-syntax
+	Paths        []string // @path
+	PathExcludes []string // @path-exclude
+
+	InsideFunc   string // @inside-func regexp
+	InsideClass  string // @inside-class regexp
+	InsideTry    bool   // @inside-try
+	NotInsideTry bool   // @not-inside-try
+	InsideLoop   bool   // @inside-loop
+	PhpVersion   string // @if-php-version, like ">=8.0"
+
+	// Filters is a list of OR-connected filter sets, like the ones separated by @or.
+	// All filters of the set must be satisfied.
+	Filters [][]VarFilter
+}
+
+// VarFilter is a pattern variable constraint.
+// Empty fields do not constrain the variable.
+type VarFilter struct {
+	// Var is a pattern variable name without $.
+	Var string
+
+	Type     string // @type, like "int|float"
+	Pure     bool   // @pure
+	Regexp   string // @filter $x regexp
+	Relation string // @filter $x relation $y, like "!same-type $y"
+	Value    string // @value $x op literal, like "> 512"
+}
+
+// Builder creates a rules set from Go code.
+//
+// It performs the same checks as the rules file Parser does.
+type Builder struct {
+	p        parser
+	fixable  map[string]bool
+	numRules int
+}
+
+// NewBuilder returns a builder of a new empty rules set.
+func NewBuilder() *Builder {
+	return &Builder{
+		p: parser{
+			filename:   "<builder>",
+			res:        NewSet(),
+			typeParser: phpdoc.NewTypeParser(),
+			names:      make(map[string]struct{}),
+		},
+		fixable: make(map[string]bool),
+	}
+}
+
+// Add validates and adds a new rule to the set.
+func (b *Builder) Add(spec RuleSpec) error {
+	b.numRules++
+	if spec.Name == "" {
+		return fmt.Errorf("rule #%d: missing Name", b.numRules)
+	}
+	if err := b.add(&spec); err != nil {
+		return fmt.Errorf("%s: %v", spec.Name, err)
+	}
+	return nil
+}
+
+// SetDoc sets the documentation of the rules with the given name,
+// like a rule function comment does in the rules file.
+func (b *Builder) SetDoc(name string, doc RuleDoc) {
+	b.p.res.DocByName[name] = doc
+}
+
+// Build returns the rules set with all added rules.
+func (b *Builder) Build() *Set {
+	res := b.p.res
+
+	res.Names = make([]string, 0, len(b.p.names))
+	for name := range b.p.names {
+		res.Names = append(res.Names, name)
+	}
+	sort.Strings(res.Names)
+
+	// Like the parser, record a doc for every rule name,
+	// even if it wasn't documented with SetDoc.
+	for _, name := range res.Names {
+		doc := res.DocByName[name]
+		if b.fixable[name] {
+			doc.Fix = true
+		}
+		res.DocByName[name] = doc
+	}
+
+	return res
+}
+
+func (b *Builder) add(spec *RuleSpec) error {
+	rule := Rule{
+		Name:         spec.Name,
+		Link:         spec.Link,
+		Level:        spec.Level,
+		Message:      spec.Message,
+		Fix:          spec.Fix,
+		StrictSyntax: spec.StrictSyntax,
+		InsideTry:    spec.InsideTry,
+		NotInsideTry: spec.NotInsideTry,
+		InsideLoop:   spec.InsideLoop,
+	}
+
+	switch spec.Level {
+	case lintapi.LevelError, lintapi.LevelWarning, lintapi.LevelNotice:
+	default:
+		return fmt.Errorf("unexpected Level %d", spec.Level)
+	}
+	if spec.Message == "" {
+		return fmt.Errorf("missing Message")
+	}
+
+	pattern, err := parsePattern(spec.Pattern)
+	if err != nil {
+		return fmt.Errorf("parse Pattern: %v", err)
+	}
+	if spec.Sequence {
+		if err := checkSeqPattern(pattern, spec.Fix != ""); err != nil {
+			return err
+		}
+	}
+
+	switch spec.Scope {
+	case "", "any", "root", "local":
+		rule.scope = spec.Scope
+	default:
+		return fmt.Errorf("unknown Scope: %s", spec.Scope)
+	}
+
+	verifiedVars := make(map[string]struct{})
+
+	if spec.Location != "" {
+		if !b.p.checkForVariableInPattern(spec.Location, pattern, verifiedVars) {
+			return fmt.Errorf("Location contains a reference to a variable %s that is not present in the pattern", spec.Location)
+		}
+		rule.Location = spec.Location
+	}
+
+	rule.Paths = spec.Paths
+	for _, path := range spec.PathExcludes {
+		if rule.PathExcludes == nil {
+			rule.PathExcludes = make(map[string]bool, len(spec.PathExcludes))
+		}
+		rule.PathExcludes[path] = true
+	}
+
+	if spec.InsideFunc != "" {
+		rule.InsideFunc, err = regexp.Compile(spec.InsideFunc)
+		if err != nil {
+			return fmt.Errorf("InsideFunc: can't compile regexp %s", err)
+		}
+	}
+	if spec.InsideClass != "" {
+		rule.InsideClass, err = regexp.Compile(spec.InsideClass)
+		if err != nil {
+			return fmt.Errorf("InsideClass: can't compile regexp %s", err)
+		}
+	}
+	if rule.InsideTry && rule.NotInsideTry {
+		return fmt.Errorf("InsideTry and NotInsideTry are mutually exclusive")
+	}
+	if spec.PhpVersion != "" {
+		rule.PhpVersion, err = ParseVersionConstraint(spec.PhpVersion)
+		if err != nil {
+			return fmt.Errorf("PhpVersion %s: %v", spec.PhpVersion, err)
+		}
+	}
+
+	for _, filters := range spec.Filters {
+		filterSet, err := b.buildFilterSet(filters, pattern, verifiedVars)
+		if err != nil {
+			return err
+		}
+		rule.Filters = append(rule.Filters, filterSet)
+	}
+
+	b.p.compiler.FuzzyMatching = !rule.StrictSyntax
+	m, err := b.p.compiler.Compile([]byte(spec.Pattern))
+	if err != nil {
+		return fmt.Errorf("pattern compilation error: %v", err)
+	}
+	rule.Matcher = m
+
+	b.p.names[rule.Name] = struct{}{}
+	if rule.Fix != "" {
+		b.fixable[rule.Name] = true
+	}
+
+	dst := b.p.ruleDst(&rule)
+	if spec.Sequence {
+		dst.AddSequence(rule)
+	} else {
+		dst.Add(ir.GetNodeKind(pattern), rule)
+	}
+	return nil
+}
+
+func (b *Builder) buildFilterSet(filters []VarFilter, pattern ir.Node, verifiedVars map[string]struct{}) (map[string]Filter, error) {
+	filterSet := make(map[string]Filter, len(filters))
+
+	for _, f := range filters {
+		name := f.Var
+		if !b.p.filterByPattern(name, pattern, verifiedVars) {
+			return nil, fmt.Errorf("filter contains a reference to a variable %s that is not present in the pattern", name)
+		}
+		filter := filterSet[name]
+
+		if f.Type != "" {
+			if filter.Type != nil {
+				return nil, fmt.Errorf("$%s: duplicate type constraint", name)
+			}
+			typ := b.p.typeParser.Parse(f.Type).Clone()
+			switch typ.Expr.Kind {
+			case phpdoc.ExprInvalid, phpdoc.ExprUnknown:
+				return nil, fmt.Errorf("$%s: parseType(%s): bad type expression", name, typ)
+			}
+			filter.Type = &typ
+		}
+
+		if f.Pure {
+			filter.Pure = true
+		}
+
+		if f.Regexp != "" {
+			re, err := regexp.Compile(f.Regexp)
+			if err != nil {
+				return nil, fmt.Errorf("$%s: can't compile regexp %s", name, err)
+			}
+			filter.Regexp = re
+		}
+
+		if f.Relation != "" {
+			params := append([]string{"$" + name}, strings.Fields(f.Relation)...)
+			if len(params) != 3 {
+				return nil, fmt.Errorf("$%s: relation %q should be like \"same-type $y\"", name, f.Relation)
+			}
+			_, rel, err := b.p.parseRelation(params, pattern, verifiedVars)
+			if err != nil {
+				return nil, fmt.Errorf("$%s: %v", name, err)
+			}
+			filter.Relations = append(filter.Relations, rel)
+		}
+
+		if f.Value != "" {
+			fields := strings.Fields(f.Value)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("$%s: value constraint %q should be like \"> 512\"", name, f.Value)
+			}
+			c, err := ParseValueConstraint(fields[0], strings.Join(fields[1:], " "))
+			if err != nil {
+				return nil, fmt.Errorf("$%s: %v", name, err)
+			}
+			filter.Values = append(filter.Values, c)
+		}
+
+		filterSet[name] = filter
+	}
+
+	return filterSet, nil
+}
+
+// parsePattern returns the pattern IR that is used for the validation.
+//
+// Unlike the compiled matcher, it keeps the ${"name:class"}
+// pattern variables as is, so they can be found by name.
+func parsePattern(pattern string) (ir.Node, error) {
+	root, _, err := parseutil.Parse([]byte(pattern))
+	if err != nil {
+		return nil, err
+	}
+	n := irconv.ConvertNode(root)
+	if st, ok := n.(*ir.ExpressionStmt); ok {
+		n = st.Expr
+	}
+	return n, nil
+}
+
+// checkSeqPattern checks the pattern like the parser checks the seq blocks.
+// Fixable sequences can't contain ${"*"} gaps.
+func checkSeqPattern(pattern ir.Node, fixable bool) error {
+	block, ok := pattern.(*ir.StmtList)
+	if !ok {
+		return fmt.Errorf("sequence Pattern must be a block of statements")
+	}
+	if len(block.Stmts) == 0 {
+		return fmt.Errorf("sequence Pattern is empty")
+	}
+	for _, i := range []int{0, len(block.Stmts) - 1} {
+		if isAnyStmt(block.Stmts[i]) {
+			return fmt.Errorf("sequence Pattern can't start or end with ${\"*\"}")
+		}
+	}
+	if fixable {
+		for _, st := range block.Stmts {
+			if isAnyStmt(st) {
+				return fmt.Errorf("Fix can't be used in a sequence Pattern with ${\"*\"}")
+			}
+		}
+	}
+	return nil
+}
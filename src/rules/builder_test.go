@@ -0,0 +1,182 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/VKCOM/noverify/src/ir"
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+)
+
+func TestBuilder(t *testing.T) {
+	b := NewBuilder()
+	b.SetDoc("deprecatedRPC", RuleDoc{
+		Comment: "Report calls of the deprecated RPC methods.",
+	})
+
+	specs := []RuleSpec{
+		{
+			Name:    "deprecatedRPC",
+			Pattern: `$rpc->call(${"method:str"}, ${"*"})`,
+			Level:   lintapi.LevelWarning,
+			Message: "$method is deprecated",
+			Filters: [][]VarFilter{
+				{{Var: "method", Regexp: `^users\.get$`}},
+			},
+		},
+		{
+			Name:    "deprecatedRPC",
+			Pattern: `rpc_call($method, $args)`,
+			Level:   lintapi.LevelWarning,
+			Message: "use $rpc->call() instead",
+			Fix:     `$rpc->call($method, $args)`,
+			Scope:   "local",
+		},
+		{
+			Name:    "intvalCall",
+			Pattern: `intval($x)`,
+			Level:   lintapi.LevelNotice,
+			Message: "use (int) cast instead",
+			Fix:     `(int)$x`,
+		},
+		{
+			Name:     "unusedResult",
+			Pattern:  `{ $x = $_; return; }`,
+			Sequence: true,
+			Level:    lintapi.LevelNotice,
+			Message:  "$x is not used",
+		},
+	}
+	for _, spec := range specs {
+		if err := b.Add(spec); err != nil {
+			t.Fatalf("add %s: %v", spec.Name, err)
+		}
+	}
+
+	rset := b.Build()
+	if diff := cmp.Diff(rset.Names, []string{"deprecatedRPC", "intvalCall", "unusedResult"}); diff != "" {
+		t.Errorf("names mismatch:\n%s", diff)
+	}
+	if !rset.DocByName["deprecatedRPC"].Fix {
+		t.Errorf("deprecatedRPC: doc is not marked as autofixable")
+	}
+	if doc, ok := rset.DocByName["intvalCall"]; !ok || !doc.Fix {
+		t.Errorf("intvalCall: undocumented rule has no autofixable doc")
+	}
+	if doc, ok := rset.DocByName["unusedResult"]; !ok || doc.Fix {
+		t.Errorf("unusedResult: have doc %+v (found %v), want a non-autofixable doc", doc, ok)
+	}
+	if n := len(rset.Any.RulesByKind[ir.KindMethodCallExpr]); n != 1 {
+		t.Errorf("expected 1 any method call rule, got %d", n)
+	}
+	if n := len(rset.Local.RulesByKind[ir.KindFunctionCallExpr]); n != 1 {
+		t.Errorf("expected 1 local function call rule, got %d", n)
+	}
+	if n := len(rset.Any.Sequences); n != 1 {
+		t.Errorf("expected 1 sequence rule, got %d", n)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	tests := []struct {
+		spec RuleSpec
+		err  string
+	}{
+		{
+			spec: RuleSpec{Pattern: `f()`, Level: lintapi.LevelError, Message: "m"},
+			err:  `rule #1: missing Name`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f()`, Message: "m"},
+			err:  `r: unexpected Level 0`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f()`, Level: lintapi.LevelError},
+			err:  `r: missing Message`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f(`, Level: lintapi.LevelError, Message: "m"},
+			err:  `r: parse Pattern: `,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m", Scope: "file"},
+			err:  `r: unknown Scope: file`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m", Location: "y"},
+			err:  `r: Location contains a reference to a variable y that is not present in the pattern`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m", InsideFunc: `(`},
+			err:  `r: InsideFunc: can't compile regexp`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m", InsideTry: true, NotInsideTry: true},
+			err:  `r: InsideTry and NotInsideTry are mutually exclusive`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m", PhpVersion: "8"},
+			err:  `r: PhpVersion 8: `,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m", Sequence: true},
+			err:  `r: sequence Pattern must be a block of statements`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `{ f($x); ${"*"}; }`, Level: lintapi.LevelError, Message: "m", Sequence: true},
+			err:  `r: sequence Pattern can't start or end with ${"*"}`,
+		},
+		{
+			spec: RuleSpec{Name: "r", Pattern: `{ f($x); ${"*"}; g($x); }`, Level: lintapi.LevelError, Message: "m", Sequence: true, Fix: `g($x);`},
+			err:  `r: Fix can't be used in a sequence Pattern with ${"*"}`,
+		},
+		{
+			spec: RuleSpec{
+				Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m",
+				Filters: [][]VarFilter{{{Var: "y", Pure: true}}},
+			},
+			err: `r: filter contains a reference to a variable y that is not present in the pattern`,
+		},
+		{
+			spec: RuleSpec{
+				Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m",
+				Filters: [][]VarFilter{{{Var: "x", Type: "int"}, {Var: "x", Type: "float"}}},
+			},
+			err: `r: $x: duplicate type constraint`,
+		},
+		{
+			spec: RuleSpec{
+				Name: "r", Pattern: `f($x, $y)`, Level: lintapi.LevelError, Message: "m",
+				Filters: [][]VarFilter{{{Var: "x", Relation: "same-color $y"}}},
+			},
+			err: `r: $x: unknown relation same-color`,
+		},
+		{
+			spec: RuleSpec{
+				Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m",
+				Filters: [][]VarFilter{{{Var: "x", Relation: "same-type"}}},
+			},
+			err: `r: $x: relation "same-type" should be like "same-type $y"`,
+		},
+		{
+			spec: RuleSpec{
+				Name: "r", Pattern: `f($x)`, Level: lintapi.LevelError, Message: "m",
+				Filters: [][]VarFilter{{{Var: "x", Value: "=> 1"}}},
+			},
+			err: `r: $x: unknown comparison operator =>`,
+		},
+	}
+
+	for _, test := range tests {
+		err := NewBuilder().Add(test.spec)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.spec.Name)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("%s: error mismatch:\nhave: %s\nwant: %s", test.spec.Name, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/VKCOM/php-parser/pkg/version"
+
+	"github.com/VKCOM/noverify/src/meta"
+)
+
+// VersionConstraint is a version comparison like ">=8.0".
+type VersionConstraint struct {
+	Op      string
+	Version version.Version
+}
+
+// ParseVersionConstraint parses the version constraint.
+// Supported operators are <, <=, >, >=, == and !=.
+func ParseVersionConstraint(s string) (*VersionConstraint, error) {
+	op, rest, ok := cutCompareOp(strings.TrimSpace(s))
+	if !ok {
+		return nil, fmt.Errorf("expected a comparison operator, like >=8.0")
+	}
+
+	v, err := version.New(strings.TrimSpace(rest))
+	if err != nil {
+		return nil, err
+	}
+	return &VersionConstraint{Op: op, Version: *v}, nil
+}
+
+// Matches reports whether v satisfies the constraint.
+func (c *VersionConstraint) Matches(v *version.Version) bool {
+	return compareOpResult(c.Op, v.Compare(&c.Version))
+}
+
+func (c *VersionConstraint) String() string {
+	return fmt.Sprintf("%s%d.%d", c.Op, c.Version.Major, c.Version.Minor)
+}
+
+// ValueConstraint is a comparison of the constant folded
+// phpgrep variable value with a literal.
+type ValueConstraint struct {
+	Op    string
+	Value meta.ConstValue
+}
+
+// ParseValueConstraint parses the value constraint operator and literal.
+// Supported operators are <, <=, >, >=, == and !=.
+//
+// The literal is an int, float, single or double quoted string, true or false.
+func ParseValueConstraint(op, literal string) (*ValueConstraint, error) {
+	parsedOp, rest, ok := cutCompareOp(op)
+	if !ok || rest != "" {
+		return nil, fmt.Errorf("unknown comparison operator %s", op)
+	}
+
+	var value meta.ConstValue
+	switch {
+	case literal == "true" || literal == "false":
+		value = meta.NewBoolConst(literal == "true")
+	case len(literal) >= 2 && literal[0] == '\'' && literal[len(literal)-1] == '\'':
+		value = meta.NewStringConst(literal[1 : len(literal)-1])
+	case strings.HasPrefix(literal, `"`):
+		v, err := strconv.Unquote(literal)
+		if err != nil {
+			return nil, fmt.Errorf("bad string literal %s", literal)
+		}
+		value = meta.NewStringConst(v)
+	default:
+		if v, err := strconv.ParseInt(literal, 0, 64); err == nil {
+			value = meta.NewIntConst(v)
+		} else if v, err := strconv.ParseFloat(literal, 64); err == nil {
+			value = meta.NewFloatConst(v)
+		} else {
+			return nil, fmt.Errorf("bad literal %s", literal)
+		}
+	}
+
+	return &ValueConstraint{Op: parsedOp, Value: value}, nil
+}
+
+// Matches reports whether v satisfies the constraint.
+//
+// Values of different kinds (e.g. a string and an int) are not comparable,
+// so they never satisfy the constraint.
+func (c *ValueConstraint) Matches(v meta.ConstValue) bool {
+	cmp, ok := compareConstValues(v, c.Value)
+	if !ok {
+		return false
+	}
+	return compareOpResult(c.Op, cmp)
+}
+
+func (c *ValueConstraint) String() string {
+	if c.Value.Type == meta.String {
+		return c.Op + " " + strconv.Quote(c.Value.GetString())
+	}
+	return fmt.Sprintf("%s %v", c.Op, c.Value.Value)
+}
+
+// compareConstValues returns -1, 0 or 1 if x is less, equal
+// or greater than y. Ints and floats are comparable with each other.
+func compareConstValues(x, y meta.ConstValue) (int, bool) {
+	isNumber := func(v meta.ConstValue) bool {
+		return v.Type == meta.Integer || v.Type == meta.Float
+	}
+	toFloat := func(v meta.ConstValue) float64 {
+		if v.Type == meta.Integer {
+			return float64(v.GetInt())
+		}
+		return v.GetFloat()
+	}
+	compare := func(less, equal bool) int {
+		switch {
+		case equal:
+			return 0
+		case less:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	switch {
+	case x.Type == meta.Integer && y.Type == meta.Integer:
+		return compare(x.GetInt() < y.GetInt(), x.GetInt() == y.GetInt()), true
+	case isNumber(x) && isNumber(y):
+		return compare(toFloat(x) < toFloat(y), toFloat(x) == toFloat(y)), true
+	case x.Type == meta.String && y.Type == meta.String:
+		return strings.Compare(x.GetString(), y.GetString()), true
+	case x.Type == meta.Bool && y.Type == meta.Bool:
+		return compare(!x.GetBool() && y.GetBool(), x.GetBool() == y.GetBool()), true
+	default:
+		return 0, false
+	}
+}
+
+// cutCompareOp splits s into the comparison operator prefix and the rest.
+func cutCompareOp(s string) (op, rest string, ok bool) {
+	for _, op := range []string{"<=", ">=", "==", "!=", "<", ">"} {
+		if strings.HasPrefix(s, op) {
+			return op, s[len(op):], true
+		}
+	}
+	return "", s, false
+}
+
+// compareOpResult reports whether the comparison result
+// cmp (-1, 0 or 1) satisfies the op operator.
+func compareOpResult(op string, cmp int) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/VKCOM/php-parser/pkg/version"
+
+	"github.com/VKCOM/noverify/src/meta"
+)
+
+func TestValueConstraint(t *testing.T) {
+	tests := []struct {
+		op      string
+		literal string
+		value   meta.ConstValue
+		want    bool
+	}{
+		{"<", "0", meta.NewIntConst(-1), true},
+		{"<", "0", meta.NewIntConst(0), false},
+		{"<=", "0", meta.NewIntConst(0), true},
+		{">", "512", meta.NewIntConst(1024), true},
+		{">", "512", meta.NewFloatConst(512.5), true},
+		{">=", "0.5", meta.NewIntConst(1), true},
+		{"==", "10", meta.NewFloatConst(10), true},
+		{"==", "0x10", meta.NewIntConst(16), true},
+		{"!=", "10", meta.NewIntConst(10), false},
+		{"==", `"r"`, meta.NewStringConst("r"), true},
+		{"==", `'r+'`, meta.NewStringConst("r+"), true},
+		{"<", `'b'`, meta.NewStringConst("a"), true},
+		{"==", "true", meta.NewBoolConst(true), true},
+		{"!=", "true", meta.NewBoolConst(false), true},
+
+		// Values of different kinds are not comparable.
+		{"==", "10", meta.NewStringConst("10"), false},
+		{"!=", "10", meta.NewStringConst("10"), false},
+		{"!=", "10", meta.UnknownValue, false},
+	}
+
+	for _, test := range tests {
+		c, err := ParseValueConstraint(test.op, test.literal)
+		if err != nil {
+			t.Errorf("parse %s %s: %v", test.op, test.literal, err)
+			continue
+		}
+		have := c.Matches(test.value)
+		if have != test.want {
+			t.Errorf("%v %s: have %v, want %v", test.value, c, have, test.want)
+		}
+	}
+}
+
+func TestVersionConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{">=8.0", "8.1", true},
+		{">= 8.0", "8.0", true},
+		{">=8.0", "7.4", false},
+		{"<8.0", "7.4", true},
+		{"==7.4", "7.4", true},
+		{"!=7.4", "8.0", true},
+	}
+
+	for _, test := range tests {
+		c, err := ParseVersionConstraint(test.constraint)
+		if err != nil {
+			t.Errorf("parse %s: %v", test.constraint, err)
+			continue
+		}
+		v, err := version.New(test.version)
+		if err != nil {
+			t.Fatalf("parse version %s: %v", test.version, err)
+		}
+		have := c.Matches(v)
+		if have != test.want {
+			t.Errorf("%s %s: have %v, want %v", test.version, test.constraint, have, test.want)
+		}
+	}
+}
@@ -25,7 +25,7 @@ type parseMode int
 const (
 	parseNormal parseMode = iota
 	parseAny
-	parseSeq // To be implemented
+	parseSeq
 )
 
 type parseError struct {
@@ -113,7 +113,7 @@ func (p *parser) tryParseLabeledStmt(stmts []ir.Node, proto *Rule) (bool, error)
 	}
 
 	if mode == parseSeq {
-		return true, p.errorf(label, "seq is not implemented yet")
+		return true, p.parseSeqRule(label, next, proto)
 	}
 
 	nextProto, err := p.parseRuleInfo(label, next, proto)
@@ -180,6 +180,12 @@ func (p *parser) parseRuleInfo(st ir.Node, labelStmt ir.Node, proto *Rule) (Rule
 		rule.Location = proto.Location
 		rule.Paths = proto.Paths
 		rule.PathExcludes = proto.PathExcludes
+		rule.InsideFunc = proto.InsideFunc
+		rule.InsideClass = proto.InsideClass
+		rule.InsideTry = proto.InsideTry
+		rule.NotInsideTry = proto.NotInsideTry
+		rule.InsideLoop = proto.InsideLoop
+		rule.PhpVersion = proto.PhpVersion
 
 		rule.Filters = make([]map[string]Filter, len(proto.Filters))
 		for i, filterSet := range proto.Filters {
@@ -306,6 +312,31 @@ func (p *parser) parseRuleInfo(st ir.Node, labelStmt ir.Node, proto *Rule) (Rule
 				rule.PathExcludes = make(map[string]bool, 1)
 			}
 			rule.PathExcludes[part.Params[0]] = true
+		case "inside-func", "inside-class":
+			if len(part.Params) != 1 {
+				return rule, p.errorf(st, "@%s expects exactly 1 param, got %d", part.Name(), len(part.Params))
+			}
+			re, err := regexp.Compile(part.Params[0])
+			if err != nil {
+				return rule, p.errorf(st, "@%s: can't compile regexp %s", part.Name(), err)
+			}
+			if part.Name() == "inside-func" {
+				rule.InsideFunc = re
+			} else {
+				rule.InsideClass = re
+			}
+		case "inside-try":
+			rule.InsideTry = true
+		case "not-inside-try":
+			rule.NotInsideTry = true
+		case "inside-loop":
+			rule.InsideLoop = true
+		case "if-php-version":
+			c, err := ParseVersionConstraint(part.ParamsText)
+			if err != nil {
+				return rule, p.errorf(st, "@if-php-version %s: %v", part.ParamsText, err)
+			}
+			rule.PhpVersion = c
 		case "type":
 			if len(part.Params) != 2 {
 				return rule, p.errorf(st, "@type expects exactly 2 params, got %d", len(part.Params))
@@ -354,7 +385,42 @@ func (p *parser) parseRuleInfo(st ir.Node, labelStmt ir.Node, proto *Rule) (Rule
 			filter := filterSet[name]
 			filter.Pure = true
 			filterSet[name] = filter
+		case "value":
+			if len(part.Params) < 3 {
+				return rule, p.errorf(st, "@value expects at least 3 params, got %d", len(part.Params))
+			}
+			name := part.Params[0]
+			if !strings.HasPrefix(name, "$") {
+				return rule, p.errorf(st, "@value 1st param must be a phpgrep variable")
+			}
+			name = strings.TrimPrefix(name, "$")
+			if !p.filterByPattern(name, patternStmt, verifiedVars) {
+				return rule, p.errorf(st, "@value contains a reference to a variable %s that is not present in the pattern", name)
+			}
+			c, err := ParseValueConstraint(part.Params[1], strings.Join(part.Params[2:], " "))
+			if err != nil {
+				return rule, p.errorf(st, "@value: %v", err)
+			}
+			if filterSet == nil {
+				filterSet = map[string]Filter{}
+			}
+			filter := filterSet[name]
+			filter.Values = append(filter.Values, c)
+			filterSet[name] = filter
 		case "filter":
+			if len(part.Params) == 3 {
+				name, rel, err := p.parseRelation(part.Params, patternStmt, verifiedVars)
+				if err != nil {
+					return rule, p.errorf(st, "@filter: %v", err)
+				}
+				if filterSet == nil {
+					filterSet = map[string]Filter{}
+				}
+				filter := filterSet[name]
+				filter.Relations = append(filter.Relations, rel)
+				filterSet[name] = filter
+				continue
+			}
 			if len(part.Params) != 2 {
 				return rule, p.errorf(st, "@filter expects exactly 2 param, got %d", len(part.Params))
 			}
@@ -386,6 +452,9 @@ func (p *parser) parseRuleInfo(st ir.Node, labelStmt ir.Node, proto *Rule) (Rule
 	if rule.Name == "" {
 		return rule, p.errorf(st, "missing @name attribute")
 	}
+	if rule.InsideTry && rule.NotInsideTry {
+		return rule, p.errorf(st, "@inside-try and @not-inside-try are mutually exclusive")
+	}
 	if p.namespace != "" {
 		rule.Name = p.namespace + "/" + rule.Name
 	}
@@ -456,22 +525,7 @@ func (p *parser) parseRule(st ir.Node, proto *Rule) error {
 		return err
 	}
 
-	dst := p.res.Any // Use "any" set by default
-	switch rule.scope {
-	case "any":
-		dst = p.res.Any
-	case "root":
-		dst = p.res.Root
-	case "local":
-		dst = p.res.Local
-	}
-
-	if rulesDoc, ok := p.res.DocByName[p.funcName]; ok {
-		if !rulesDoc.Fix && rule.Fix != "" {
-			rulesDoc.Fix = true
-			p.res.DocByName[p.funcName] = rulesDoc
-		}
-	}
+	dst := p.ruleDst(&rule)
 
 	pos := ir.GetPosition(st)
 	p.compiler.FuzzyMatching = !rule.StrictSyntax
@@ -489,6 +543,116 @@ func (p *parser) parseRule(st ir.Node, proto *Rule) error {
 	return nil
 }
 
+// parseSeqRule parses a seq-labeled block into a single rule
+// that matches a contiguous sequence of statements.
+func (p *parser) parseSeqRule(label *ir.LabelStmt, block *ir.StmtList, proto *Rule) error {
+	rule, err := p.parseRuleInfo(label, block, proto)
+	if err != nil {
+		return err
+	}
+
+	if rule.Name == "" {
+		return p.errorf(label, "seq block is not annotated with a rule comment")
+	}
+	if len(block.Stmts) == 0 {
+		return p.errorf(label, "seq block is empty")
+	}
+	for _, i := range []int{0, len(block.Stmts) - 1} {
+		if isAnyStmt(block.Stmts[i]) {
+			return p.errorf(block.Stmts[i], "seq block can't start or end with ${\"*\"}")
+		}
+	}
+	if rule.Fix != "" {
+		// The fix replaces the entire matched sequence,
+		// so the statements matched by a gap would be lost.
+		for _, st := range block.Stmts {
+			if isAnyStmt(st) {
+				return p.errorf(st, "@fix can't be used in a seq block with ${\"*\"}")
+			}
+		}
+	}
+
+	dst := p.ruleDst(&rule)
+
+	pos := ir.GetPosition(block)
+	p.compiler.FuzzyMatching = !rule.StrictSyntax
+	m, err := p.compiler.Compile(p.sources[pos.StartPos-1 : pos.EndPos])
+	if err != nil {
+		return p.errorf(label, "pattern compilation error: %v", err)
+	}
+	rule.Matcher = m
+
+	dst.AddSequence(rule)
+	return nil
+}
+
+// ruleDst returns the rules set for the rule scope.
+// It also updates the rule function docs if necessary.
+func (p *parser) ruleDst(rule *Rule) *ScopedSet {
+	if rulesDoc, ok := p.res.DocByName[p.funcName]; ok {
+		if !rulesDoc.Fix && rule.Fix != "" {
+			rulesDoc.Fix = true
+			p.res.DocByName[p.funcName] = rulesDoc
+		}
+	}
+
+	switch rule.scope {
+	case "root":
+		return p.res.Root
+	case "local":
+		return p.res.Local
+	default:
+		return p.res.Any // Use "any" set by default
+	}
+}
+
+// isAnyStmt reports whether st is a ${"*"} statement.
+func isAnyStmt(st ir.Node) bool {
+	e, ok := st.(*ir.ExpressionStmt)
+	if !ok {
+		return false
+	}
+	v, ok := e.Expr.(*ir.Var)
+	if !ok {
+		return false
+	}
+	s, ok := v.Expr.(*ir.String)
+	return ok && s.Value == "*"
+}
+
+// parseRelation parses the `$x op $y` relational filter params.
+// It returns the $x variable name and the parsed relation.
+func (p *parser) parseRelation(params []string, pattern ir.Node, verifiedVars map[string]struct{}) (string, Relation, error) {
+	var rel Relation
+
+	name := params[0]
+	other := params[2]
+	if !strings.HasPrefix(name, "$") || !strings.HasPrefix(other, "$") {
+		return "", rel, fmt.Errorf("relation operands must be phpgrep variables")
+	}
+	name = strings.TrimPrefix(name, "$")
+	other = strings.TrimPrefix(other, "$")
+	for _, v := range []string{name, other} {
+		if !p.filterByPattern(v, pattern, verifiedVars) {
+			return "", rel, fmt.Errorf("contains a reference to a variable %s that is not present in the pattern", v)
+		}
+	}
+
+	opName := params[1]
+	if strings.HasPrefix(opName, "!") {
+		rel.Negated = true
+		opName = opName[len("!"):]
+	}
+	op, ok := relationOps[opName]
+	if !ok {
+		return "", rel, fmt.Errorf("unknown relation %s", params[1])
+	}
+	rel.Op = op
+	rel.Other = other
+
+	return name, rel, nil
+}
+
 func (p *parser) parseFuncComment(fn *ir.FunctionStmt) error {
 	if fn.Doc.Raw == "" {
 		return nil
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"fmt"
 	"io"
 	"regexp"
 
@@ -38,6 +39,7 @@ type Set struct {
 	Root    *ScopedSet // Only outside of functions
 	Local   *ScopedSet // Only inside functions
 	Builtin bool       // Whether this is a NoVerify builtin rule set
+	Pack    string     // Name of the embedded rule pack, if any
 
 	Names     []string // All rule names
 	DocByName map[string]RuleDoc
@@ -47,7 +49,12 @@ type Set struct {
 // Categories help to assign a better execution strategy for a rule.
 type ScopedSet struct {
 	RulesByKind [ir.NumKinds][]Rule
-	CountRules  int
+
+	// Sequences are the rules that match a contiguous
+	// statements sequence instead of a single node.
+	Sequences []Rule
+
+	CountRules int
 }
 
 func (s *ScopedSet) Add(kind ir.NodeKind, rule Rule) {
@@ -55,6 +62,16 @@ func (s *ScopedSet) Add(kind ir.NodeKind, rule Rule) {
 	s.CountRules++
 }
 
+func (s *ScopedSet) AddSequence(rule Rule) {
+	s.Sequences = append(s.Sequences, rule)
+	s.CountRules++
+}
+
+func (s *ScopedSet) SetSequences(rules []Rule) {
+	s.Sequences = append(s.Sequences, rules...)
+	s.CountRules += len(rules)
+}
+
 func (s *ScopedSet) Set(kind ir.NodeKind, rules []Rule) {
 	s.RulesByKind[kind] = append(s.RulesByKind[kind], rules...)
 	s.CountRules += len(rules)
@@ -113,6 +130,25 @@ type Rule struct {
 	// Every filter set is a mapping of phpgrep variable to a filter.
 	Filters []map[string]Filter
 
+	// InsideFunc is a context constraint that is matched against
+	// the enclosing function or method name.
+	InsideFunc *regexp.Regexp
+
+	// InsideClass is a context constraint that is matched against
+	// the enclosing class fully qualified name.
+	InsideClass *regexp.Regexp
+
+	// InsideTry and NotInsideTry require the matched code
+	// to be inside or outside of a try block respectively.
+	InsideTry    bool
+	NotInsideTry bool
+
+	// InsideLoop requires the matched code to be inside a loop.
+	InsideLoop bool
+
+	// PhpVersion is a PHP version constraint for the analyzed code.
+	PhpVersion *VersionConstraint
+
 	scope string
 }
 
@@ -126,4 +162,64 @@ type Filter struct {
 	Type   *phpdoc.Type
 	Pure   bool
 	Regexp *regexp.Regexp
+
+	// Relations are the constraints between this
+	// and the other phpgrep variables.
+	Relations []Relation
+
+	// Values are the constraints on the constant folded variable value.
+	Values []*ValueConstraint
+}
+
+// RelationOp is a kind of the relation between two phpgrep variables.
+type RelationOp int
+
+const (
+	// RelationSameType requires both variables to have the same inferred type.
+	RelationSameType RelationOp = iota
+
+	// RelationSubtypeOf requires every type of the variable
+	// to be the other variable type or its subclass.
+	RelationSubtypeOf
+
+	// RelationSameText requires the variables to have the same source text.
+	RelationSameText
+
+	// RelationSameValue requires both variables to be constant
+	// expressions that are folded to the same value.
+	RelationSameValue
+)
+
+var relationOps = map[string]RelationOp{
+	"same-type":  RelationSameType,
+	"subtype-of": RelationSubtypeOf,
+	"same-text":  RelationSameText,
+	"same-value": RelationSameValue,
+}
+
+// Relation is a constraint between two phpgrep variables.
+type Relation struct {
+	Op RelationOp
+
+	// Negated inverts the relation result.
+	//
+	// Note that if a relation can't be evaluated (e.g. types are unknown),
+	// the filter is not satisfied regardless of the negation.
+	Negated bool
+
+	// Other is the other phpgrep variable name.
+	Other string
+}
+
+func (r Relation) String() string {
+	for name, op := range relationOps {
+		if op != r.Op {
+			continue
+		}
+		if r.Negated {
+			return "!" + name + " $" + r.Other
+		}
+		return name + " $" + r.Other
+	}
+	return fmt.Sprintf("Relation(%d) $%s", r.Op, r.Other)
 }
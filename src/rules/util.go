@@ -42,6 +42,25 @@ func formatRule(r *Rule) string {
 		buf.WriteString(" * @scope " + r.scope + "\n")
 	}
 
+	if r.InsideFunc != nil {
+		buf.WriteString(" * @inside-func " + r.InsideFunc.String() + "\n")
+	}
+	if r.InsideClass != nil {
+		buf.WriteString(" * @inside-class " + r.InsideClass.String() + "\n")
+	}
+	if r.InsideTry {
+		buf.WriteString(" * @inside-try\n")
+	}
+	if r.NotInsideTry {
+		buf.WriteString(" * @not-inside-try\n")
+	}
+	if r.InsideLoop {
+		buf.WriteString(" * @inside-loop\n")
+	}
+	if r.PhpVersion != nil {
+		buf.WriteString(" * @if-php-version " + r.PhpVersion.String() + "\n")
+	}
+
 	for i, filters := range r.Filters {
 		for name, filter := range filters {
 			if filter.Type != nil {
@@ -49,6 +68,12 @@ func formatRule(r *Rule) string {
 				buf.WriteString(filter.Type.String())
 				buf.WriteString(" $" + name + "\n")
 			}
+			for _, c := range filter.Values {
+				buf.WriteString(" * @value $" + name + " " + c.String() + "\n")
+			}
+			for _, rel := range filter.Relations {
+				buf.WriteString(" * @filter $" + name + " " + rel.String() + "\n")
+			}
 		}
 		if i != len(r.Filters)-1 {
 			buf.WriteString(" * @or\n")
@@ -0,0 +1,123 @@
+package checkers_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/linttest"
+	"github.com/VKCOM/noverify/src/quickfix"
+)
+
+func TestSuggestUndefinedSymbols(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.AddFile(`<?php
+namespace App\Models;
+
+const MAX_USERS = 10;
+
+function load_user($id) { return new User(); }
+
+class User {
+  const ROLE_ADMIN = 1;
+  public $name = '';
+  public static $count = 0;
+
+  /** @return string */
+  public function getName() { return $this->name; }
+}
+`)
+	test.AddFile(`<?php
+namespace App\Http;
+
+use App\Models\User;
+
+function f(User $u) {
+  $_ = new Usr();
+  $_ = \App\Models\load_usr(1);
+  $_ = \App\Models\MAX_USER;
+  $_ = $u->getNme();
+  $_ = $u->nam;
+  $_ = User::ROLE_ADMN;
+  $_ = User::$cont;
+  $_ = User::getName2();
+  $_ = new Completely\Different();
+}
+`)
+	test.Expect = []string{
+		`Class or interface named \App\Http\Usr does not exist (did you mean \App\Models\User?)`,
+		`Call to undefined function \App\Models\load_usr (did you mean \App\Models\load_user?)`,
+		`Undefined constant \App\Models\MAX_USER (did you mean \App\Models\MAX_USERS?)`,
+		`Call to undefined method {\App\Models\User}->getNme() (did you mean getName?)`,
+		`Property {\App\Models\User}->nam does not exist (did you mean name?)`,
+		`Class constant \App\Models\User::ROLE_ADMN does not exist (did you mean ROLE_ADMIN?)`,
+		`Property \App\Models\User::$cont does not exist (did you mean $count?)`,
+		`Call to undefined method \App\Models\User::getName2() (did you mean getName?)`,
+		`Class or interface named \App\Http\Completely\Different does not exist`,
+	}
+	test.RunAndMatch()
+}
+
+func TestSuggestClassImport(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.AddFile(`<?php
+namespace App\Models;
+
+class Post {}
+`)
+	test.AddFile(`<?php
+namespace App\Http;
+
+function f() {
+  $_ = new Post();
+}
+`)
+	test.Expect = []string{
+		`Class or interface named \App\Http\Post does not exist (did you mean \App\Models\Post?)`,
+	}
+	test.RunAndMatch()
+}
+
+func TestSuggestClassImportWithoutNamespace(t *testing.T) {
+	var mu sync.Mutex
+	fixed := make(map[string]string)
+
+	config := linter.NewConfig("8.1")
+	config.ApplyQuickFixes = true
+	config.QuickFixHandler = func(filename string, contents []byte, fixes []quickfix.TextEdit) error {
+		mu.Lock()
+		defer mu.Unlock()
+		fixed[filename] = string(quickfix.Render(contents, fixes))
+		return nil
+	}
+
+	test := linttest.NewSuite(t)
+	test.UseConfig(config)
+	test.AddNamedFile("post.php", `<?php
+namespace App\Models;
+
+class Post {}
+`)
+	test.AddNamedFile("index.php", `<?php
+
+function f() {
+  return new Post();
+}
+`)
+	test.Expect = []string{
+		`Class or interface named \Post does not exist (did you mean \App\Models\Post?)`,
+	}
+	test.RunAndMatch()
+
+	want := `<?php
+
+use App\Models\Post;
+
+function f() {
+  return new Post();
+}
+`
+	if fixed["index.php"] != want {
+		t.Errorf("unexpected fix result:\n%s", fixed["index.php"])
+	}
+}
@@ -0,0 +1,193 @@
+package checkers_test
+
+import (
+	"testing"
+
+	"github.com/VKCOM/noverify/src/linttest"
+)
+
+func TestUnusedParam(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.Config().UnusedParams = true
+	test.AddFile(`<?php
+function f($x, $y, $_) {
+  return $x;
+}
+
+function withFuncGetArgs($x) {
+  return func_get_args();
+}
+
+function byRef(&$x) {}
+
+function clobber($x) {
+  $x = 10;
+  return $x;
+}
+
+function usedInClosure($x) {
+  return function() use ($x) { return $x; };
+}
+
+function usedInArrowFunc($x) {
+  return fn() => $x;
+}
+`)
+	test.Expect = []string{
+		`Param $y is unused`,
+		`Param $x re-assigned before being used`,
+	}
+	test.RunAndMatch()
+}
+
+func TestUnusedParamMethods(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.Config().UnusedParams = true
+	test.AddFile(`<?php
+interface Handler {
+  /** @param int $x */
+  public function handle($x);
+}
+
+abstract class Base {
+  /** @param int $x */
+  public function run($x) { return 1; }
+
+  /** @param int $x */
+  abstract public function abstractRun($x);
+}
+
+class Impl extends Base implements Handler {
+  /** @param int $x */
+  public function handle($x) { return 1; }
+
+  /** @param int $x */
+  public function run($x) { return 2; }
+
+  /** @param int $x */
+  public function abstractRun($x) { return 3; }
+
+  /** @param int $x */
+  public function own($x) { return 4; }
+
+  /** @param string $name */
+  public function __get($name) { return 0; }
+
+  public function __construct(private int $promoted, int $unused) {}
+}
+`)
+	test.Expect = []string{
+		`Param $x is unused`,
+		`Param $x is unused`,
+		`Param $unused is unused`,
+	}
+	test.RunAndMatch()
+}
+
+func TestUnusedParamDisabled(t *testing.T) {
+	linttest.SimpleNegativeTest(t, `<?php
+function f($x) {
+  return 10;
+}
+`)
+}
+
+func TestUnusedPrivateMember(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.Config().UnusedPrivateMembers = true
+	test.AddFile(`<?php
+function call_later(callable $fn) {}
+
+class Foo {
+  const PUBLIC_CONST = 1;
+  private const USED = 2;
+  private const UNUSED = 3;
+
+  private $used = 1;
+  private $unused = 2;
+  private static $usedStatic = 3;
+  private $usedByOther = 4;
+
+  public function __construct(private int $promotedUsed, private int $promotedUnused) {}
+
+  /** @return int */
+  public function f(Foo $other) {
+    $this->usedMethod();
+    self::usedStaticMethod();
+    call_later([$this, 'usedAsCallable']);
+    return self::USED + $this->used + self::$usedStatic + $other->usedByOther + $this->promotedUsed;
+  }
+
+  private function usedMethod() {}
+  private static function usedStaticMethod() {}
+  private function usedAsCallable() {}
+  private function unusedMethod() {}
+  private function __clone() {}
+}
+`)
+	test.Expect = []string{
+		`Private constant Foo::UNUSED is unused`,
+		`Private property Foo::$unused is unused`,
+		`Private property Foo::$promotedUnused is unused`,
+		`Private method Foo::unusedMethod is unused`,
+	}
+	test.RunAndMatch()
+}
+
+func TestUnusedPrivateMemberDynamicAccess(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.Config().UnusedPrivateMembers = true
+	test.AddFile(`<?php
+class Foo {
+  private $a = 1;
+  private $b = 2;
+
+  /** @param string $name */
+  public function get($name) {
+    return $this->$name;
+  }
+
+  private function unused() {}
+}
+`)
+	test.Expect = []string{
+		`Private method Foo::unused is unused`,
+	}
+	test.RunAndMatch()
+}
+
+func TestUnusedPrivateMemberTraits(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.Config().UnusedPrivateMembers = true
+	test.AddFile(`<?php
+trait Base {
+  /** @return int */
+  public function base() {
+    return $this->usedInTrait() + self::TRAIT_CONST;
+  }
+}
+
+trait Derived {
+  use Base;
+
+  /** @return int */
+  public function derived() {
+    return $this->usedInNestedTrait;
+  }
+}
+
+class Foo {
+  use Derived;
+
+  private const TRAIT_CONST = 1;
+  private $usedInNestedTrait = 1;
+  private $unused = 1;
+
+  private function usedInTrait() { return 1; }
+}
+`)
+	test.Expect = []string{
+		`Private property Foo::$unused is unused`,
+	}
+	test.RunAndMatch()
+}
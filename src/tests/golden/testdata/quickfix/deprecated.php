@@ -0,0 +1,59 @@
+<?php
+
+use JetBrains\PhpStorm\Deprecated;
+
+#[Deprecated(replacement: "new_join(%parametersList%)")]
+function old_join($glue, $pieces) {
+  return implode($glue, $pieces);
+}
+
+/** @param string[] $pieces */
+function new_join($glue, $pieces) {
+  return implode($glue, $pieces);
+}
+
+#[Deprecated(replacement: "new_first(%parameter1%)")]
+function old_first($unused, $pieces) {
+  return $pieces[0];
+}
+
+/** @param string[] $pieces */
+function new_first($pieces) {
+  return $pieces[0];
+}
+
+/**
+ * @deprecated
+ * @see new_join()
+ */
+function hint_only() {}
+
+class Str {
+  #[Deprecated(replacement: "%class%::concat(%parameter0%, %parameter1%)")]
+  public static function join2($a, $b) {
+    return $a . $b;
+  }
+
+  public static function concat($a, $b) {
+    return $a . $b;
+  }
+
+  #[Deprecated(replacement: "%class%->newLen(%parametersList%)")]
+  public function len($s) {
+    return strlen($s);
+  }
+
+  public function newLen($s) {
+    return strlen($s);
+  }
+}
+
+function test() {
+  $s = new Str();
+  $_ = old_join(",", ["a", "b"]);
+  $_ = old_first(1, ["a"]);
+  $_ = old_first(1);
+  hint_only();
+  $_ = Str::join2("a", "b");
+  $_ = $s->len("abc");
+}
@@ -0,0 +1,19 @@
+<?php
+
+namespace App\Models;
+
+class Post {}
+
+class Comment {}
+
+namespace App\Http;
+
+use App\Models\Comment;
+
+function show(Post $post) {
+  return new Post();
+}
+
+function comment() {
+  return new Comment();
+}
@@ -0,0 +1,28 @@
+<?php
+
+namespace App;
+
+interface Shape {
+  const DEFAULT_SIZE = 10;
+
+  /**
+   * @param float $scale
+   * @return float
+   */
+  public function area(float $scale = 1.0): float;
+
+  /** @param int[] $out */
+  public function collect(array &$out, ?Shape $other = null, string ...$tags);
+
+  public static function create(int $size = self::DEFAULT_SIZE): self;
+}
+
+abstract class Base {
+  abstract protected function name(): string;
+}
+
+class Square extends Base implements Shape {
+  private $size = 1;
+}
+
+class Circle implements Shape {}
@@ -0,0 +1,60 @@
+package rules_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/VKCOM/noverify/src/linter/lintapi"
+	"github.com/VKCOM/noverify/src/linttest"
+	"github.com/VKCOM/noverify/src/rules"
+)
+
+func TestRuleBuilder(t *testing.T) {
+	// Rules like these are generated from a list of the deprecated methods.
+	deprecated := map[string]string{
+		"users.get":  "users.getById",
+		"wall.post2": "wall.post",
+	}
+
+	b := rules.NewBuilder()
+	for method, replacement := range deprecated {
+		err := b.Add(rules.RuleSpec{
+			Name:    "deprecatedRPC",
+			Pattern: `rpc_call(${"method:str"}, ${"*"})`,
+			Level:   lintapi.LevelWarning,
+			Message: method + " is deprecated, use " + replacement + " instead",
+			Filters: [][]rules.VarFilter{
+				{{Var: "method", Regexp: "^" + regexp.QuoteMeta(method) + "$"}},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := b.Add(rules.RuleSpec{
+		Name:       "deprecatedRPC",
+		Pattern:    `rpc_call_async($_, ${"*"})`,
+		Level:      lintapi.LevelNotice,
+		Message:    "rpc_call_async is deprecated since PHP 8",
+		PhpVersion: ">=8.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test := linttest.NewSuite(t)
+	test.AddFile(`<?php
+function f() {
+  rpc_call('users.get', 1);
+  rpc_call('users.getById', 1);
+  rpc_call("wall.post2", ['text' => 'hi']);
+  rpc_call_async('users.get');
+}
+`)
+	test.Expect = []string{
+		`users.get is deprecated, use users.getById instead`,
+		`wall.post2 is deprecated, use wall.post instead`,
+		`rpc_call_async is deprecated since PHP 8`,
+	}
+	test.RunRuleSetTest(b.Build())
+}
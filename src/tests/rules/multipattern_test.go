@@ -1,9 +1,15 @@
 package rules_test
 
 import (
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/VKCOM/noverify/src/linttest"
+	"github.com/VKCOM/noverify/src/quickfix"
+	"github.com/VKCOM/noverify/src/rules"
 )
 
 func TestRuleMultiAny(t *testing.T) {
@@ -95,3 +101,216 @@ function good_arraycmp(array $a, array $a2) {
 
 	test.RunRulesTest()
 }
+
+func TestRuleMultiSeq(t *testing.T) {
+	rfile := `<?php
+function assignReturn() {
+  /**
+   * @maybe $x is assigned and immediately returned
+   * @fix return $e;
+   */
+  seq: {
+    $x = $e;
+    return $x;
+  }
+}
+
+function overwrite() {
+  /**
+   * @warning $x is overwritten before being used
+   * @location $y
+   */
+  seq_overwrite: {
+    $x = $_;
+    $x = $y;
+  }
+}
+
+function reopen() {
+  /**
+   * @warning $f is reopened before being closed
+   * @location $f
+   */
+  seq_reopen: {
+    $f = fopen($_, $_);
+    ${"*"};
+    $f = fopen($_, $_);
+  }
+}
+`
+	test := linttest.NewSuite(t)
+	test.RuleFile = rfile
+	test.AddFile(`<?php
+function bad_return() {
+  $a = g();
+  return $a;
+}
+
+function bad_nested_return($cond) {
+  if ($cond) {
+    $x = 1 + 2;
+    return $x;
+  }
+  return 0;
+}
+
+function bad_overwrite() {
+  $b = 1;
+  $b = 2;
+  echo $b;
+}
+
+function bad_reopen() {
+  $f = fopen('a', 'r');
+  echo 1;
+  echo 2;
+  $f = fopen('b', 'r');
+  fclose($f);
+}
+
+function good_return() {
+  $a = g();
+  echo $a;
+  return $a;
+}
+
+function good_overwrite() {
+  $b = 1;
+  $c = 2;
+  $b = $b + $c;
+  echo $b;
+}
+
+function good_reopen() {
+  $f = fopen('a', 'r');
+  fclose($f);
+  $g = fopen('b', 'r');
+  fclose($g);
+}
+`)
+	test.AddFile(`<?php
+$top = 1;
+$top = 2;
+echo $top;
+`)
+	test.Expect = []string{
+		`$a is assigned and immediately returned`,
+		`$x is assigned and immediately returned`,
+		`$b is overwritten before being used`,
+		`$top is overwritten before being used`,
+		`$f is reopened before being closed`,
+	}
+
+	test.RunRulesTest()
+}
+
+func TestRuleMultiSeqLocation(t *testing.T) {
+	rfile := `<?php
+function unusedBeforeReturn() {
+  /**
+   * @warning $x is computed but not used
+   * @location $unused
+   */
+  seq: {
+    ${"unused:var"} = $_;
+    ${"*"};
+    return $_;
+  }
+}
+`
+	rset, err := rules.NewParser().Parse("<test>", strings.NewReader(rfile))
+	if err != nil {
+		t.Fatalf("parse rules: %v", err)
+	}
+
+	test := linttest.NewSuite(t)
+	test.Config().Rules = rset
+	test.IgnoreUndeclaredChecks()
+	test.AddFile(`<?php
+function f() {
+  echo 1;
+  $tmp = 10;
+  echo 2;
+  return 0;
+}
+`)
+
+	var lines []int
+	for _, r := range test.RunLinter().Reports {
+		if r.CheckName == "unusedBeforeReturn" {
+			lines = append(lines, r.Line)
+		}
+	}
+
+	want := []int{4}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("report lines differ:\n%s", diff)
+	}
+}
+
+func TestRuleMultiSeqFix(t *testing.T) {
+	rfile := `<?php
+function assignReturn() {
+  /**
+   * @maybe $x is assigned and immediately returned
+   * @fix return $e;
+   */
+  seq: {
+    $x = $e;
+    return $x;
+  }
+}
+
+function reopen() {
+  /**
+   * @warning $f is reopened before being closed
+   */
+  seq_reopen: {
+    $f = fopen($_, $_);
+    ${"*"};
+    $f = fopen($_, $_);
+  }
+}
+`
+	rset, err := rules.NewParser().Parse("<test>", strings.NewReader(rfile))
+	if err != nil {
+		t.Fatalf("parse rules: %v", err)
+	}
+
+	var mu sync.Mutex
+	var fixed string
+	test := linttest.NewSuite(t)
+	test.Config().Rules = rset
+	test.Config().ApplyQuickFixes = true
+	test.Config().QuickFixHandler = func(filename string, contents []byte, fixes []quickfix.TextEdit) error {
+		mu.Lock()
+		defer mu.Unlock()
+		fixed = string(quickfix.Render(contents, fixes))
+		return nil
+	}
+	test.IgnoreUndeclaredChecks()
+	test.AddFile(`<?php
+function f() {
+  $f = fopen('a', 'r');
+  echo 1;
+  $a = g();
+  $f = fopen('b', 'r');
+  $r = fread($f, 10);
+  return $r;
+}
+`)
+	test.RunLinter()
+
+	want := `<?php
+function f() {
+  $f = fopen('a', 'r');
+  echo 1;
+  $a = g();
+  $f = fopen('b', 'r');
+  return fread($f, 10);
+}
+`
+	if diff := cmp.Diff(want, fixed); diff != "" {
+		t.Errorf("fixed file differs:\n%s", diff)
+	}
+}
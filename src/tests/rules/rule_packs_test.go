@@ -0,0 +1,170 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/VKCOM/noverify/src/cmd"
+	"github.com/VKCOM/noverify/src/linter"
+	"github.com/VKCOM/noverify/src/linttest"
+)
+
+func parseRulePacks(t *testing.T) map[string]*cmd.RulePack {
+	t.Helper()
+
+	packs, err := cmd.ParseEmbeddedRulePacks()
+	if err != nil {
+		t.Fatalf("parse rule packs: %v", err)
+	}
+
+	byName := make(map[string]*cmd.RulePack, len(packs))
+	for _, pack := range packs {
+		byName[pack.Name] = pack
+	}
+	return byName
+}
+
+func TestRulePacksDeclare(t *testing.T) {
+	config := linter.NewConfig("8.1")
+	if err := linttest.InitEmbeddedRules(config); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pack := range parseRulePacks(t) {
+		if pack.Version == "" || pack.Comment == "" {
+			t.Errorf("%s: version and comment are required", pack.Name)
+		}
+		if len(pack.Rules.Names) == 0 {
+			t.Errorf("%s: no rules", pack.Name)
+		}
+		for _, name := range pack.Rules.Names {
+			if pack.Rules.DocByName[name].Comment == "" {
+				t.Errorf("%s: %s rule has no @comment", pack.Name, name)
+			}
+		}
+
+		// DeclareRules panics if a pack rule name is already taken.
+		config.Checkers.DeclareRules(pack.Rules)
+	}
+
+	for _, info := range config.Checkers.ListDeclared() {
+		if info.Name == "unsafeUnserialize" && info.Pack != "security" {
+			t.Errorf("unsafeUnserialize: have pack %q, want security", info.Pack)
+		}
+	}
+}
+
+func TestRulePackSecurity(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.AddFile(`<?php
+function f($data, $dir, $s) {
+  unserialize($data);
+  unserialize($data, ['allowed_classes' => false]);
+  exec("ls $dir");
+  extract($_GET);
+  extract($row);
+  echo md5($s);
+}
+`)
+	test.Expect = []string{
+		`Call unserialize with the 'allowed_classes' option to restrict the objects it creates`,
+		`Running shell commands is dangerous, make sure the arguments are escaped`,
+		`Don't extract the user input, it can overwrite any local variable`,
+		`md5 is a weak hash, use password_hash() for passwords and hash_hmac() with sha256 for signatures`,
+	}
+	test.RunRuleSetTest(parseRulePacks(t)["security"].Rules)
+}
+
+func TestRulePackPerformance(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.AddFile(`<?php
+function f($xs, $key) {
+  $dst = array_merge([], $xs);
+  foreach ($xs as $x) {
+    $dst = array_merge($dst, $x);
+  }
+  for ($i = 0; $i < count($xs); $i++) {
+    echo $i;
+  }
+  return in_array($key, array_keys($xs));
+}
+`)
+	test.Expect = []string{
+		`array_merge inside a loop copies $dst on every iteration, collect the arrays and merge them once`,
+		`count($xs) is evaluated on every iteration, save it to a variable before the loop`,
+		`Could rewrite as ` + "`array_key_exists($key, $xs)`",
+	}
+	test.RunRuleSetTest(parseRulePacks(t)["performance"].Rules)
+}
+
+func TestRulePackPHP8Migration(t *testing.T) {
+	const code = `<?php
+function f($s) {
+  if (strpos($s, "a") !== false) {}
+  if (strpos($s, "a") === 0) {}
+  if (false === strpos($s, "b")) {}
+  return create_function('$x', 'return $x;');
+}
+`
+	test := linttest.NewSuite(t)
+	test.AddFile(code)
+	test.Expect = []string{
+		`Could rewrite as ` + "`str_contains($s, \"a\")`",
+		`Could rewrite as ` + "`str_starts_with($s, \"a\")`",
+		`Could rewrite as ` + "`!str_contains($s, \"b\")`",
+		`create_function was removed in PHP 8.0, use a closure instead`,
+	}
+	test.RunRuleSetTest(parseRulePacks(t)["php8-migration"].Rules)
+
+	// The suggestions are not reported for PHP 7, only the removed functions are.
+	test = linttest.NewPHP7Suite(t)
+	test.AddFile(code)
+	test.Expect = []string{
+		`create_function was removed in PHP 8.0, use a closure instead`,
+	}
+	test.RunRuleSetTest(parseRulePacks(t)["php8-migration"].Rules)
+}
+
+func TestRulePackPHPUnit(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.AddFile(`<?php
+class FooTest {
+  public function testFoo($x, $xs) {
+    $this->assertSame(true, $x);
+    $this->assertSame(3, count($xs));
+    $this->assertSame(3, $x);
+    $this->assertEquals(null, $x);
+    $this->assertEquals(3, count($xs));
+  }
+}
+`)
+	test.Expect = []string{
+		`Could rewrite as ` + "`$this->assertTrue($x)`",
+		`Could rewrite as ` + "`$this->assertCount(3, $xs)`",
+		`Could rewrite as ` + "`$this->assertNull($x)`",
+		`Could rewrite as ` + "`$this->assertCount(3, $xs)`",
+	}
+	test.RunRuleSetTest(parseRulePacks(t)["phpunit"].Rules)
+}
+
+func TestRulePackKPHP(t *testing.T) {
+	test := linttest.NewSuite(t)
+	test.AddFile(`<?php
+function f($o, $name) {
+  $$name = 1;
+  $o->$name();
+  $o->method();
+  $_ = new $name;
+  $_ = new $name(1, 2);
+  $_ = new Foo(1, 2);
+  return new $name();
+}
+`)
+	test.Expect = []string{
+		`Variable variables are not supported by KPHP`,
+		`Calling a method by its dynamic name is not supported by KPHP`,
+		`Creating an object of a dynamic class is not supported by KPHP`,
+		`Creating an object of a dynamic class is not supported by KPHP`,
+		`Creating an object of a dynamic class is not supported by KPHP`,
+	}
+	test.RunRuleSetTest(parseRulePacks(t)["kphp"].Rules)
+}
@@ -336,6 +336,156 @@ for ($x = $y; $x < $z; $x++) {
 `,
 			expect: "<test>:11: @location contains a reference to a variable q that is not present in the pattern",
 		},
+		{
+			name: `InsideTryAndNotInsideTry`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ * @inside-try
+ * @not-inside-try
+ */
+f();
+`,
+			expect: "<test>:8: @inside-try and @not-inside-try are mutually exclusive",
+		},
+		{
+			name: `InsideFuncBadRegexp`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ * @inside-func (
+ */
+f();
+`,
+			expect: "<test>:7: @inside-func: can't compile regexp error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: `IfPhpVersionWithoutOperator`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ * @if-php-version 8.0
+ */
+f();
+`,
+			expect: "<test>:7: @if-php-version 8.0: expected a comparison operator, like >=8.0",
+		},
+		{
+			name: `FilterUnknownRelation`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ * @filter $x same-color $y
+ */
+$x == $y;
+`,
+			expect: "<test>:7: @filter: unknown relation same-color",
+		},
+		{
+			name: `FilterRelationUnknownVariable`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ * @filter $x !same-text $z
+ */
+$x == $y;
+`,
+			expect: "<test>:7: @filter: contains a reference to a variable z that is not present in the pattern",
+		},
+		{
+			name: `ValueBadOperator`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ * @value $x => 10
+ */
+f($x);
+`,
+			expect: "<test>:7: @value: unknown comparison operator =>",
+		},
+		{
+			name: `ValueBadLiteral`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ * @value $x == PAGE_SIZE
+ */
+f($x);
+`,
+			expect: "<test>:7: @value: bad literal PAGE_SIZE",
+		},
+		{
+			name: `SeqWithoutComment`,
+			rule: `<?php
+seq: {
+  $x = $y;
+  return $x;
+}
+`,
+			expect: "<test>:2: seq block is not annotated with a rule comment",
+		},
+		{
+			name: `SeqStartsWithAny`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ */
+seq: {
+  ${"*"};
+  return $x;
+}
+`,
+			expect: "<test>:7: seq block can't start or end with ${\"*\"}",
+		},
+		{
+			name: `SeqEndsWithAny`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ */
+seq_some: {
+  $x = $y;
+  ${"*"};
+}
+`,
+			expect: "<test>:8: seq block can't start or end with ${\"*\"}",
+		},
+		{
+			name: `SeqEmpty`,
+			rule: `<?php
+/**
+ * @name Some
+ * @maybe Some
+ */
+seq: {}
+`,
+			expect: "<test>:6: seq block is empty",
+		},
+		{
+			name: `SeqFixWithAny`,
+			rule: `<?php
+/**
+ * @name Some
+ * @warning $f is reopened before being closed
+ * @fix $f = fopen($a, $b);
+ */
+seq: {
+  $f = fopen($_, $_);
+  ${"*"};
+  $f = fopen($a, $b);
+}
+`,
+			expect: "<test>:9: @fix can't be used in a seq block with ${\"*\"}",
+		},
 	}
 
 	runRulesErrorTest(t, tests)
@@ -1,9 +1,13 @@
 package rules_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/VKCOM/noverify/src/linttest"
+	"github.com/VKCOM/noverify/src/rules"
 )
 
 func TestRuleBlock(t *testing.T) {
@@ -1057,3 +1061,273 @@ eval(${"var"});
 	}
 	test.RunRulesTest()
 }
+
+func TestRuleCaptures(t *testing.T) {
+	rfile := `<?php
+/**
+ * @name inArray
+ * @warning in_array call
+ */
+in_array($x, $arr);
+`
+	rset, err := rules.NewParser().Parse("<test>", strings.NewReader(rfile))
+	if err != nil {
+		t.Fatalf("parse rules: %v", err)
+	}
+
+	test := linttest.NewSuite(t)
+	test.Config().Rules = rset
+	test.Config().CollectRuleCaptures = true
+	test.IgnoreUndeclaredChecks()
+	test.AddFile(`<?php
+function f($name, $names) {
+  return in_array($name, $names);
+}
+`)
+
+	var captures []map[string]string
+	for _, r := range test.RunLinter().Reports {
+		if r.CheckName == "inArray" {
+			captures = append(captures, r.Captures)
+		}
+	}
+
+	want := []map[string]string{
+		{"x": "$name", "arr": "$names"},
+	}
+	if diff := cmp.Diff(want, captures); diff != "" {
+		t.Errorf("captures differ:\n%s", diff)
+	}
+}
+
+func TestRuleContextConstraints(t *testing.T) {
+	rfile := `<?php
+function queryInLoop() {
+  /**
+   * @warning db_query inside a loop
+   * @inside-loop
+   */
+  db_query(${"*"});
+}
+
+function unhandledRisky() {
+  /**
+   * @warning risky() is called outside of try
+   * @inside-func ^handle
+   * @not-inside-try
+   */
+  risky();
+}
+
+function handledRisky() {
+  /**
+   * @warning risky() is called inside try
+   * @inside-try
+   */
+  risky();
+}
+
+function dumpInController() {
+  /**
+   * @warning var_dump in controller
+   * @inside-class Controller$
+   */
+  var_dump(${"*"});
+}
+`
+	test := linttest.NewSuite(t)
+	test.RuleFile = rfile
+	test.AddFile(`<?php
+class UserController {
+  public function index() {
+    var_dump(1);
+    foreach ([1, 2] as $x) {
+      db_query($x);
+    }
+    db_query(0);
+  }
+}
+
+class Other {
+  public function index() {
+    var_dump(1);
+  }
+}
+
+function handleRequest() {
+  try {
+    risky();
+  } catch (Exception $e) {
+    risky();
+  }
+  risky();
+}
+
+function helper() {
+  risky();
+  while (true) {
+    $f = function() { db_query(1); };
+  }
+}
+`)
+	test.Expect = []string{
+		`var_dump in controller`,
+		`db_query inside a loop`,
+		`risky() is called inside try`,
+		`risky() is called outside of try`,
+		`risky() is called outside of try`,
+	}
+	test.RunRulesTest()
+}
+
+func TestRulePhpVersionConstraint(t *testing.T) {
+	rfile := `<?php
+function strContains() {
+  /**
+   * @maybe use str_contains
+   * @if-php-version >=8.0
+   */
+  strpos($_, $_) !== false;
+}
+`
+	code := `<?php
+function f($s) {
+  return strpos($s, 'a') !== false;
+}
+`
+
+	test := linttest.NewSuite(t)
+	test.RuleFile = rfile
+	test.AddFile(code)
+	test.Expect = []string{`use str_contains`}
+	test.RunRulesTest()
+
+	test = linttest.NewPHP7Suite(t)
+	test.RuleFile = rfile
+	test.AddFile(code)
+	test.RunRulesTest()
+}
+
+func TestRuleRelationalFilters(t *testing.T) {
+	rfile := `<?php
+function unrelatedCompare() {
+  /**
+   * @warning comparing values of unrelated classes
+   * @filter $x !subtype-of $y
+   * @filter $y !subtype-of $x
+   */
+  $x == $y;
+}
+
+function sameTypeMax() {
+  /**
+   * @warning max of same typed values
+   * @filter $x same-type $y
+   */
+  max($x, $y);
+}
+
+function constCompare() {
+  /**
+   * @warning constant is compared to its own value
+   * @filter $x same-value $y
+   * @filter $x !same-text $y
+   */
+  $x === $y;
+}
+`
+	test := linttest.NewSuite(t)
+	test.RuleFile = rfile
+	test.AddFile(`<?php
+interface Shape {}
+class Circle implements Shape {}
+class Ring extends Circle {}
+class User {}
+
+const ONE = 1;
+
+function f(Circle $circle, Ring $ring, Shape $shape, User $user, $mixed) {
+  $_ = $circle == $ring;
+  $_ = $ring == $shape;
+  $_ = $mixed == $user;
+  $_ = $circle == $user; // Bad
+
+  $_ = max(1, 2); // Bad
+  $_ = max(1, 'a');
+  $_ = max($mixed, $mixed);
+
+  $_ = ONE === 1; // Bad
+  $_ = ONE === 2;
+  $_ = 1 === 1;
+  $_ = $mixed === 1;
+}
+`)
+	test.Expect = []string{
+		`comparing values of unrelated classes`,
+		`max of same typed values`,
+		`constant is compared to its own value`,
+	}
+	test.RunRulesTest()
+}
+
+func TestRuleValueFilter(t *testing.T) {
+	rfile := `<?php
+function negativeSliceLength() {
+  /**
+   * @warning array_slice with a negative length
+   * @value $length < 0
+   */
+  array_slice($_, $_, $length);
+}
+
+function jsonDepth() {
+  /**
+   * @warning json_decode depth is out of range
+   * @value $depth > 512
+   * @or
+   * @value $depth <= 0
+   */
+  json_decode($_, $_, $depth);
+}
+
+function readMode() {
+  /**
+   * @warning file is opened for reading
+   * @value $mode == 'r'
+   */
+  fopen($_, $mode);
+}
+`
+	test := linttest.NewSuite(t)
+	test.RuleFile = rfile
+	test.AddFile(`<?php
+const PAGE_SIZE = -10;
+
+class Limits {
+  const DEPTH = 1024;
+}
+
+function f(array $a, int $n) {
+  $_ = array_slice($a, 0, PAGE_SIZE); // Bad
+  $_ = array_slice($a, 0, -1); // Bad
+  $_ = array_slice($a, 0, 5);
+  $_ = array_slice($a, 0, $n);
+
+  $_ = json_decode('', true, Limits::DEPTH); // Bad
+  $_ = json_decode('', true, 0); // Bad
+  $_ = json_decode('', true, 512);
+  $_ = json_decode('', true, '1024');
+
+  $_ = fopen('a', "r"); // Bad
+  $_ = fopen('a', 'w');
+}
+`)
+	test.Expect = []string{
+		`array_slice with a negative length`,
+		`array_slice with a negative length`,
+		`json_decode depth is out of range`,
+		`json_decode depth is out of range`,
+		`file is opened for reading`,
+	}
+	test.RunRulesTest()
+}
@@ -0,0 +1,181 @@
+// Package textdiff implements the line-based text diff
+// in the unified format, like the one produced by "diff -u".
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines around every change.
+const contextLines = 3
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns the unified diff between the old and new texts.
+// The result is empty if the texts are equal.
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+
+	// oldLine and newLine are the numbers of lines before ops[i].
+	oldLines := make([]int, len(ops)+1)
+	newLines := make([]int, len(ops)+1)
+	for i, o := range ops {
+		oldLines[i+1] = oldLines[i]
+		newLines[i+1] = newLines[i]
+		if o.kind != opInsert {
+			oldLines[i+1]++
+		}
+		if o.kind != opDelete {
+			newLines[i+1]++
+		}
+	}
+
+	for _, h := range hunks(ops) {
+		from, to := h[0], h[1]
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(oldLines[from], oldLines[to]-oldLines[from]),
+			hunkRange(newLines[from], newLines[to]-newLines[from]))
+		for _, o := range ops[from:to] {
+			sb.WriteByte(byte(o.kind))
+			sb.WriteString(o.line)
+			if !strings.HasSuffix(o.line, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// hunkRange formats the hunk range, the start is the number of lines before the hunk.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+// hunks returns the [from, to) ops ranges of the hunks.
+func hunks(ops []op) [][2]int {
+	var result [][2]int
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		from := i - contextLines
+		if from < 0 {
+			from = 0
+		}
+		to := i + 1 + contextLines
+		if to > len(ops) {
+			to = len(ops)
+		}
+		if len(result) != 0 && from <= result[len(result)-1][1] {
+			result[len(result)-1][1] = to
+		} else {
+			result = append(result, [2]int{from, to})
+		}
+	}
+	return result
+}
+
+// splitLines splits the text into lines keeping the line endings.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the shortest edit script with the Myers algorithm.
+func diffLines(a, b []string) []op {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+
+	// trace[d] is the state of v before the d-th step.
+	var trace [][]int
+
+	done := false
+	for d := 0; d <= n+m && !done; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+	}
+
+	var ops []op
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, op{kind: opEqual, line: a[x-1]})
+			x--
+			y--
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			ops = append(ops, op{kind: opInsert, line: b[y-1]})
+			y--
+		} else {
+			ops = append(ops, op{kind: opDelete, line: a[x-1]})
+			x--
+		}
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
@@ -0,0 +1,129 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "equal",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "change",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want: `--- a
++++ b
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+`,
+		},
+		{
+			name: "insert at start",
+			old:  "b\n",
+			new:  "a\nb\n",
+			want: `--- a
++++ b
+@@ -1 +1,2 @@
++a
+ b
+`,
+		},
+		{
+			name: "two hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			new:  "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			want: `--- a
++++ b
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -7,4 +7,4 @@
+ 7
+ 8
+ 9
+-10
++ten
+`,
+		},
+		{
+			name: "no newline",
+			old:  "a\nb",
+			new:  "a\nc",
+			want: `--- a
++++ b
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++c
+\ No newline at end of file
+`,
+		},
+		{
+			name: "delete all",
+			old:  "a\n",
+			new:  "",
+			want: `--- a
++++ b
+@@ -1 +0,0 @@
+-a
+`,
+		},
+	}
+
+	for _, test := range tests {
+		have := Unified("a", "b", test.old, test.new)
+		if have != test.want {
+			t.Errorf("%s:\nhave:\n%s\nwant:\n%s", test.name, have, test.want)
+		}
+	}
+}
+
+func TestDiffLinesMinimal(t *testing.T) {
+	a := splitLines("a\nb\nc\na\nb\nb\na\n")
+	b := splitLines("c\nb\na\nb\na\nc\n")
+	ops := diffLines(a, b)
+
+	changes := 0
+	var oldText, newText strings.Builder
+	for _, o := range ops {
+		if o.kind != opEqual {
+			changes++
+		}
+		if o.kind != opInsert {
+			oldText.WriteString(o.line)
+		}
+		if o.kind != opDelete {
+			newText.WriteString(o.line)
+		}
+	}
+
+	if oldText.String() != strings.Join(a, "") || newText.String() != strings.Join(b, "") {
+		t.Fatalf("ops don't restore the texts: %s", fmt.Sprint(ops))
+	}
+	// The example from the Myers paper has the edit distance of 5.
+	if changes != 5 {
+		t.Errorf("have %d changes, want 5", changes)
+	}
+}
@@ -203,6 +203,59 @@ func ReadFilesFromGitWithChanges(repo, commitSHA1 string, changes []git.Change,
 	}
 }
 
+// ReadStagedChanges returns callback that reads the index (staged) versions of the changed files
+func ReadStagedChanges(repo string, changes []git.Change, phpExtensions []string) ReadCallback {
+	changedMap := make(map[string][]git.LineRange, len(changes))
+	filenames := make([]string, 0, len(changes))
+	for _, ch := range changes {
+		if ch.Type == git.Deleted {
+			continue
+		}
+		if !isPHPExtension(ch.NewName, phpExtensions) {
+			continue
+		}
+
+		if _, ok := changedMap[ch.NewName]; !ok {
+			filenames = append(filenames, ch.NewName)
+		}
+		changedMap[ch.NewName] = append(changedMap[ch.NewName], ch.LineRanges...)
+	}
+
+	return func(ch chan FileInfo) {
+		if len(filenames) == 0 {
+			return
+		}
+
+		blobs, err := git.StagedBlobs(repo, filenames)
+		if err != nil {
+			log.Fatalf("Could not get staged files: %s", err.Error())
+		}
+
+		catter, err := git.NewCatter(repo)
+		if err != nil {
+			log.Fatalf("Could not start catter: %s", err.Error())
+		}
+
+		for _, filename := range filenames {
+			blob, ok := blobs[filename]
+			if !ok {
+				continue
+			}
+
+			obj, err := catter.Get(blob)
+			if err != nil {
+				log.Fatalf("Could not read staged file %s: %s", filename, err.Error())
+			}
+
+			ch <- FileInfo{
+				Name:       filename,
+				Contents:   obj.Contents,
+				LineRanges: changedMap[filename],
+			}
+		}
+	}
+}
+
 func makePHPExtensionSuffixes(phpExtensions []string) [][]byte {
 	res := make([][]byte, 0, len(phpExtensions))
 	for _, ext := range phpExtensions {
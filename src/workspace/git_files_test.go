@@ -0,0 +1,88 @@
+package workspace
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/VKCOM/noverify/src/git"
+)
+
+func TestReadStagedChanges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+		}
+	}
+	writeFile := func(filename, contents string) {
+		t.Helper()
+		filename = filepath.Join(dir, filepath.FromSlash(filename))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runGit("init", "-q")
+	runGit("config", "user.name", "test")
+	runGit("config", "user.email", "test@example.com")
+	writeFile("modified.php", "<?php\n")
+	writeFile("deleted.php", "<?php\n")
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+
+	writeFile("modified.php", "<?php\nf();\n")
+	writeFile("new dir/added file.php", "<?php\ng();\n")
+	writeFile("readme.txt", "text\n")
+	runGit("add", ".")
+	runGit("rm", "-q", "deleted.php")
+	writeFile("modified.php", "<?php\nunstaged();\n")
+
+	changes := []git.Change{
+		{Type: git.Changed, OldName: "modified.php", NewName: "modified.php", LineRanges: []git.LineRange{{From: 2, To: 2}}},
+		{Type: git.Added, OldName: "/dev/null", NewName: "new dir/added file.php", LineRanges: []git.LineRange{{From: 1, To: 2}}},
+		{Type: git.Deleted, OldName: "deleted.php", NewName: "/dev/null"},
+		{Type: git.Added, OldName: "/dev/null", NewName: "readme.txt"},
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd) // nolint:errcheck
+
+	var have []FileInfo
+	ch := make(chan FileInfo)
+	go func() {
+		ReadStagedChanges(filepath.Join(dir, ".git"), changes, []string{"php"})(ch)
+		close(ch)
+	}()
+	for info := range ch {
+		have = append(have, info)
+	}
+
+	want := []FileInfo{
+		{Name: "modified.php", Contents: []byte("<?php\nf();\n"), LineRanges: []git.LineRange{{From: 2, To: 2}}},
+		{Name: "new dir/added file.php", Contents: []byte("<?php\ng();\n"), LineRanges: []git.LineRange{{From: 1, To: 2}}},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected files (-want +have):\n%s", diff)
+	}
+}